@@ -0,0 +1,318 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+//#include <stdlib.h>
+//#include "ctlib.h"
+import "C"
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"unsafe"
+)
+
+// paramFormat is the format parameters are passed to Client-Library
+// with.
+type paramFormat struct {
+	datatype  ASEType
+	format    int
+	maxlength int
+}
+
+// batchParam holds the data of one parameter for all rows of a batch.
+type batchParam struct {
+	// format is the format of the values of all rows, valid if set is
+	// true.
+	format paramFormat
+	set    bool
+	// data and datalens contain the bytes and lengths for each row,
+	// data is nil for null values.
+	data     [][]byte
+	datalens []int
+	// size is the size of the buffer bound with ct_setparam.
+	size int
+}
+
+// ExecBatch executes the prepared statement once for each row of
+// arguments and returns the number of affected rows of each execution.
+//
+// The parameters are bound once with ct_setparam and each further row
+// is sent with ct_send_params, avoiding to set up the parameters for
+// each execution. Locators cannot be passed in batches.
+//
+// ExecBatch is available on the driver.Stmt returned by
+// Connection.Prepare. With database/sql it is reached through
+// Connection.ExecBatch.
+func (stmt *statement) ExecBatch(ctx context.Context, rows [][]driver.Value) ([]int64, error) {
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	params, err := stmt.batchParams(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	datafmts := make([]*C.CS_DATAFMT, len(params))
+	for i, param := range params {
+		datafmts[i] = (*C.CS_DATAFMT)(C.calloc(1, C.sizeof_CS_DATAFMT))
+		defer C.free(unsafe.Pointer(datafmts[i]))
+
+		if err := param.initFormat(datafmts[i], stmt.columnTypes[i]); err != nil {
+			return nil, fmt.Errorf("Error in argument %d: %w", i+1, err)
+		}
+	}
+
+	// Client-Library reads the bound buffers when sending, hence
+	// these must be C memory.
+	buffers := make([]unsafe.Pointer, len(params))
+	for i, param := range params {
+		buffers[i] = C.calloc((C.ulong)(param.size), C.sizeof_CS_BYTE)
+		defer C.free(buffers[i])
+	}
+
+	var datalens []C.CS_INT
+	var indicators []C.CS_SMALLINT
+	if len(params) > 0 {
+		datalens = unsafe.Slice((*C.CS_INT)(C.calloc((C.ulong)(len(params)), C.sizeof_CS_INT)), len(params))
+		defer C.free(unsafe.Pointer(&datalens[0]))
+		indicators = unsafe.Slice((*C.CS_SMALLINT)(C.calloc((C.ulong)(len(params)), C.sizeof_CS_SMALLINT)), len(params))
+		defer C.free(unsafe.Pointer(&indicators[0]))
+	}
+
+	// fill copies the arguments of a row into the bound buffers.
+	fill := func(row int) {
+		for i, param := range params {
+			buf := unsafe.Slice((*byte)(buffers[i]), param.size)
+			for j := range buf {
+				buf[j] = 0
+			}
+
+			if param.data[row] == nil {
+				datalens[i] = 0
+				indicators[i] = -1
+				continue
+			}
+
+			copy(buf, param.data[row])
+			datalens[i] = (C.CS_INT)(param.datalens[row])
+			indicators[i] = 0
+		}
+	}
+
+	name := C.CString(stmt.name)
+	defer C.free(unsafe.Pointer(name))
+
+	stmt.cmd.conn.resetMessages()
+
+	retval := C.ct_dynamic(stmt.cmd.cmd, C.CS_EXECUTE, name, C.CS_NULLTERM, nil, C.CS_UNUSED)
+	if retval != C.CS_SUCCEED {
+		return nil, makeError(retval, "C.ct_dynamic with CS_EXECUTE failed")
+	}
+
+	fill(0)
+	for i := range params {
+		retval = C.ct_setparam(stmt.cmd.cmd, datafmts[i], buffers[i], &datalens[i], &indicators[i])
+		if retval != C.CS_SUCCEED {
+			stmt.cmd.Cancel()
+			return nil, makeError(retval, "C.ct_setparam on argument %d failed", i+1)
+		}
+	}
+
+	affected := make([]int64, len(rows))
+	for row := range rows {
+		if row == 0 {
+			retval = C.ct_send(stmt.cmd.cmd)
+		} else {
+			fill(row)
+			stmt.cmd.conn.resetMessages()
+			retval = C.ct_send_params(stmt.cmd.cmd, C.CS_UNUSED)
+		}
+		if retval != C.CS_SUCCEED {
+			stmt.cmd.Cancel()
+			return affected[:row], makeError(retval, "Failed to send row %d", row+1)
+		}
+
+		resultRows, result, err := stmt.cmd.ConsumeResponse(ctx)
+		if err != nil {
+			return affected[:row], fmt.Errorf("Error executing row %d: %w", row+1, err)
+		}
+
+		if resultRows != nil {
+			resultRows.Close()
+			return affected[:row], fmt.Errorf("Row %d returned a result set", row+1)
+		}
+
+		affected[row] = -1
+		if result != nil {
+			affected[row] = result.rowsAffected
+		}
+	}
+
+	return affected, nil
+}
+
+// batchParams converts the arguments of rows and returns the data of
+// each parameter. The formats are widened to hold the values of all
+// rows.
+func (stmt *statement) batchParams(rows [][]driver.Value) ([]*batchParam, error) {
+	params := make([]*batchParam, stmt.argCount)
+	for i := range params {
+		params[i] = &batchParam{
+			data:     make([][]byte, len(rows)),
+			datalens: make([]int, len(rows)),
+			size:     1,
+		}
+	}
+
+	// rowFmt is only filled by paramData and not passed to
+	// Client-Library, hence it does not need to be C memory.
+	rowFmt := &C.CS_DATAFMT{}
+
+	for row, values := range rows {
+		if len(values) != stmt.argCount {
+			return nil, fmt.Errorf("Mismatched argument count in row %d - expected %d, got %d",
+				row+1, stmt.argCount, len(values))
+		}
+
+		for i, value := range values {
+			nv := driver.NamedValue{Ordinal: i + 1, Value: value}
+			if err := stmt.CheckNamedValue(&nv); err != nil {
+				return nil, fmt.Errorf("Error checking argument %d of row %d: %w", i+1, row+1, err)
+			}
+
+			if nv.Value == nil {
+				continue
+			}
+
+			if _, ok := nv.Value.(*Locator); ok {
+				return nil, fmt.Errorf("Locator passed for argument %d, locators are not supported in batches", i+1)
+			}
+
+			*rowFmt = C.CS_DATAFMT{}
+			if err := initParamFormat(rowFmt, "", stmt.columnTypes[i]); err != nil {
+				return nil, fmt.Errorf("Error in argument %d of row %d: %w", i+1, row+1, err)
+			}
+
+			bs, datalen, err := stmt.cmd.paramData(i+1, rowFmt, stmt.columnTypes[i], stmt.columnConverters[i], nv.Value)
+			if err != nil {
+				return nil, fmt.Errorf("Error in row %d: %w", row+1, err)
+			}
+
+			if bs == nil {
+				bs = []byte{}
+			}
+			params[i].data[row] = bs
+			params[i].datalens[row] = datalen
+
+			format := paramFormat{
+				datatype:  ASEType(rowFmt.datatype),
+				format:    int(rowFmt.format),
+				maxlength: int(rowFmt.maxlength),
+			}
+			if err := params[i].widen(format, len(bs)); err != nil {
+				return nil, fmt.Errorf("Error in argument %d of row %d: %w", i+1, row+1, err)
+			}
+		}
+	}
+
+	return params, nil
+}
+
+// widen folds the format of the parameter with the format of a value of
+// the passed size, so that the parameter holds the values of all rows.
+//
+// Values exceeding CS_MAX_CHAR bytes are passed as long types, see
+// varLenParam. Once a row requires a long type all rows are passed as
+// such. Other differing datatypes or formats cannot be passed in one
+// batch.
+func (param *batchParam) widen(format paramFormat, size int) error {
+	if !param.set {
+		param.format = format
+		param.set = true
+	}
+
+	datatype, err := widenDatatype(param.format.datatype, format.datatype)
+	if err != nil {
+		return err
+	}
+
+	if format.format != param.format.format {
+		return fmt.Errorf("Conflicting formats %d and %d", param.format.format, format.format)
+	}
+
+	param.format.datatype = datatype
+	if format.maxlength > param.format.maxlength {
+		param.format.maxlength = format.maxlength
+	}
+
+	if size > param.size {
+		param.size = size
+	}
+
+	return nil
+}
+
+// widenDatatype returns the datatype holding values of both passed
+// datatypes.
+func widenDatatype(a, b ASEType) (ASEType, error) {
+	switch {
+	case a == b:
+		return a, nil
+	case (a == CHAR && b == LONGCHAR) || (a == LONGCHAR && b == CHAR):
+		return LONGCHAR, nil
+	case (a == BINARY && b == LONGBINARY) || (a == LONGBINARY && b == BINARY):
+		return LONGBINARY, nil
+	default:
+		return 0, fmt.Errorf("Conflicting datatypes %s and %s", a, b)
+	}
+}
+
+// initFormat initializes datafmt for a parameter of the passed type
+// with the format folded over all rows.
+func (param *batchParam) initFormat(datafmt *C.CS_DATAFMT, t ASEType) error {
+	if err := initParamFormat(datafmt, "", t); err != nil {
+		return err
+	}
+
+	// The format of parameters with only null values is the format
+	// of their type.
+	if !param.set {
+		return nil
+	}
+
+	datafmt.datatype = (C.CS_INT)(param.format.datatype)
+	datafmt.format = (C.CS_INT)(param.format.format)
+	datafmt.maxlength = (C.CS_INT)(param.format.maxlength)
+	return nil
+}
+
+// ExecBatch prepares query and executes it once for each row of
+// arguments, see statement.ExecBatch.
+//
+// ExecBatch is accessible through sql.Conn.Raw.
+func (conn *Connection) ExecBatch(ctx context.Context, query string, rows [][]driver.Value) ([]int64, error) {
+	if conn.stmtCache != nil {
+		stmt, err := conn.cachedStatement(ctx, query, false)
+		if err != nil {
+			return nil, err
+		}
+
+		affected, err := stmt.ExecBatch(ctx, rows)
+		if err != nil {
+			conn.invalidateFailedStatement(query, false)
+		}
+		return affected, err
+	}
+
+	stmt, err := conn.prepare(ctx, query, false)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	return stmt.ExecBatch(ctx, rows)
+}
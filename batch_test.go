@@ -0,0 +1,167 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"database/sql/driver"
+	"strings"
+	"testing"
+)
+
+// batchStatement returns a statement with parameters of the passed
+// types, which is not prepared on a server.
+func batchStatement(types ...ASEType) *statement {
+	return &statement{
+		argCount:         len(types),
+		columnTypes:      types,
+		columnPrecisions: make([]int, len(types)),
+		columnScales:     make([]int, len(types)),
+		columnConverters: make([]TypeConverter, len(types)),
+		cmd:              &Command{conn: &Connection{}},
+	}
+}
+
+func TestBatchParams(t *testing.T) {
+	long := strings.Repeat("x", maxCharLength+1)
+
+	stmt := batchStatement(INT, VARCHAR, VARBINARY)
+	params, err := stmt.batchParams([][]driver.Value{
+		{int64(1), "short", nil},
+		{nil, long, nil},
+		{int64(3), "", nil},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(params) != 3 {
+		t.Fatalf("Expected 3 parameters, received %d", len(params))
+	}
+
+	// The int parameter is passed with its fixed size, the null
+	// value of the second row has no data.
+	if !params[0].set || params[0].format.datatype != INT {
+		t.Errorf("Expected int parameter, received %+v", params[0].format)
+	}
+	if params[0].data[1] != nil {
+		t.Errorf("Expected nil data for null value, received %v", params[0].data[1])
+	}
+
+	// The long value of the second row widens the parameter to a long
+	// type for all rows.
+	if params[1].format.datatype != LONGCHAR {
+		t.Errorf("Expected %s, received %s", LONGCHAR, params[1].format.datatype)
+	}
+	if params[1].size != len(long) || params[1].format.maxlength != len(long) {
+		t.Errorf("Expected size and maxlength %d, received %d and %d",
+			len(long), params[1].size, params[1].format.maxlength)
+	}
+	if expected := []int{5, len(long), 0}; params[1].datalens[0] != expected[0] ||
+		params[1].datalens[1] != expected[1] || params[1].datalens[2] != expected[2] {
+		t.Errorf("Expected datalens %v, received %v", expected, params[1].datalens)
+	}
+	if params[1].data[2] == nil {
+		t.Errorf("Expected empty string to be passed as non-null value")
+	}
+
+	// Parameters with only null values keep the format of their type.
+	if params[2].set {
+		t.Errorf("Expected no format for parameter with only null values, received %+v", params[2].format)
+	}
+}
+
+func TestBatchParamsErrors(t *testing.T) {
+	cases := map[string]struct {
+		rows [][]driver.Value
+		err  string
+	}{
+		"mismatched count": {
+			rows: [][]driver.Value{{int64(1), "a"}, {int64(2)}},
+			err:  "row 2",
+		},
+		"invalid value": {
+			rows: [][]driver.Value{{int64(1), "a"}, {"b", "c"}},
+			err:  "argument 1 of row 2",
+		},
+		"locator": {
+			rows: [][]driver.Value{{int64(1), &Locator{Type: TEXTLOCATOR}}},
+			err:  "argument 2",
+		},
+	}
+
+	for title, cas := range cases {
+		t.Run(title, func(t *testing.T) {
+			_, err := batchStatement(INT, VARCHAR).batchParams(cas.rows)
+			if err == nil || !strings.Contains(err.Error(), cas.err) {
+				t.Errorf("Expected error containing %q, received %v", cas.err, err)
+			}
+		})
+	}
+}
+
+func TestBatchParamWiden(t *testing.T) {
+	cases := map[string]struct {
+		formats  []paramFormat
+		expected paramFormat
+		err      bool
+	}{
+		"same format": {
+			formats:  []paramFormat{{INT, 0, 4}, {INT, 0, 4}},
+			expected: paramFormat{INT, 0, 4},
+		},
+		"maxlength": {
+			formats:  []paramFormat{{CHAR, 0, 3}, {CHAR, 0, 10}, {CHAR, 0, 5}},
+			expected: paramFormat{CHAR, 0, 10},
+		},
+		"long char": {
+			formats:  []paramFormat{{CHAR, 0, 3}, {LONGCHAR, 0, 300}, {CHAR, 0, 5}},
+			expected: paramFormat{LONGCHAR, 0, 300},
+		},
+		"long binary first": {
+			formats:  []paramFormat{{LONGBINARY, 2, 300}, {BINARY, 2, 1}},
+			expected: paramFormat{LONGBINARY, 2, 300},
+		},
+		"conflicting datatypes": {
+			formats: []paramFormat{{CHAR, 0, 3}, {BINARY, 0, 3}},
+			err:     true,
+		},
+		"conflicting formats": {
+			formats: []paramFormat{{BINARY, 2, 3}, {BINARY, 0, 3}},
+			err:     true,
+		},
+	}
+
+	for title, cas := range cases {
+		t.Run(title, func(t *testing.T) {
+			param := &batchParam{size: 1}
+
+			var err error
+			for i, format := range cas.formats {
+				if err = param.widen(format, i+1); err != nil {
+					break
+				}
+			}
+
+			if cas.err {
+				if err == nil {
+					t.Errorf("Expected error, received %+v", param.format)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if param.format != cas.expected {
+				t.Errorf("Expected %+v, received %+v", cas.expected, param.format)
+			}
+
+			if param.size != len(cas.formats) {
+				t.Errorf("Expected size %d, received %d", len(cas.formats), param.size)
+			}
+		})
+	}
+}
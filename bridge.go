@@ -28,12 +28,15 @@ func SetCallbackTarget(target *os.File) {
 
 // srvMsg is a callback function which will be called from C when the
 // server sends a message. The message is then passed to the
-// GlobalServerMessageBroker.
+// GlobalServerMessageBroker and to the connection it was received on.
 // Don't change the following line. It is the directive for cgo to make
 // the function available from C.
 //export srvMsg
-func srvMsg(msg *C.CS_SERVERMSG) C.CS_RETCODE {
+func srvMsg(con *C.CS_CONNECTION, msg *C.CS_SERVERMSG) C.CS_RETCODE {
 	GlobalServerMessageBroker.recvServerMessage(msg)
+	if conn, ok := connections.Load(con); ok {
+		conn.(*Connection).recvServerMessage(msg)
+	}
 	return C.CS_SUCCEED
 }
 
@@ -0,0 +1,262 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+)
+
+// ColumnBatch contains the values of one column for the rows of a
+// Batch. Depending on the type of the column the values are stored in
+// exactly one of Int64s, Float64s, Strings or Values.
+type ColumnBatch struct {
+	Name string
+	Type ASEType
+
+	// Int64s contains the values of integer and bit columns.
+	Int64s []int64
+	// Float64s contains the values of float and real columns.
+	Float64s []float64
+	// Strings contains the values of character columns.
+	Strings []string
+	// Values contains the values of all other columns as returned by
+	// Rows.Next.
+	Values []interface{}
+
+	// Nulls is a bitmap of the null values, bit i%64 of Nulls[i/64]
+	// is set if the value of row i is null. Null values are stored as
+	// zero values.
+	Nulls []uint64
+}
+
+// IsNull returns true if the value of the passed row is null.
+func (col *ColumnBatch) IsNull(row int) bool {
+	return col.Nulls[row/64]&(1<<uint(row%64)) != 0
+}
+
+// setNull marks the value of the passed row as null.
+func (col *ColumnBatch) setNull(row int) {
+	for len(col.Nulls) <= row/64 {
+		col.Nulls = append(col.Nulls, 0)
+	}
+	col.Nulls[row/64] |= 1 << uint(row%64)
+}
+
+// Batch contains the values of a number of rows column by column.
+type Batch struct {
+	// Len is the number of rows in the batch.
+	Len     int
+	Columns []*ColumnBatch
+}
+
+// columnKind is the slice of a ColumnBatch values of a column are
+// stored in.
+type columnKind int
+
+const (
+	kindValue columnKind = iota
+	kindInt64
+	kindFloat64
+	kindString
+)
+
+// columnKindOf returns the columnKind of columns of the passed ASEType.
+func columnKindOf(t ASEType) columnKind {
+	switch t {
+	case BIT, TINYINT, SMALLINT, USMALLINT, USHORT, INT, UINT, BIGINT, LONG:
+		return kindInt64
+	case REAL, FLOAT:
+		return kindFloat64
+	case CHAR, VARCHAR, TEXT, LONGCHAR, UNICHAR, UNIVARCHAR, UNITEXT:
+		return kindString
+	default:
+		return kindValue
+	}
+}
+
+// NextBatch reads up to n rows and returns their values column by
+// column. Integer, float and character columns are read directly from
+// the memory Client-Library fetched the rows into, avoiding to convert
+// each value into a driver.Value.
+//
+// NextBatch reads the rows fetched by Client-Library and does not
+// change how many rows are fetched at once. With the default
+// fetch-batch-size of 1 each row still requires a call to ct_fetch, the
+// fetch-batch-size property should be set to the same order as n to
+// fetch the rows with few calls to ct_fetch.
+//
+// The returned batch contains less than n rows if the result set is
+// exhausted. io.EOF is returned if no rows are left. NextBatch cannot
+// be used on result sets with LOB columns streamed with lob-streaming.
+func (rows *Rows) NextBatch(n int) (*Batch, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("Batch size must be positive, got %d", n)
+	}
+
+	kinds := make([]columnKind, rows.numCols)
+	batch := &Batch{Columns: make([]*ColumnBatch, rows.numCols)}
+	for i, name := range rows.Columns() {
+		if rows.lobColumns[i] {
+			return nil, fmt.Errorf("Column %d is a streamed LOB column, which cannot be read in batches", i+1)
+		}
+
+		if rows.colConverters[i] == nil && rows.colFallback[i] == 0 {
+			kinds[i] = columnKindOf(rows.colASEType[i])
+		}
+
+		batch.Columns[i] = &ColumnBatch{Name: name, Type: rows.colASEType[i]}
+	}
+
+	for batch.Len < n {
+		if err := rows.advance(); err != nil {
+			if err == io.EOF && batch.Len > 0 {
+				break
+			}
+			return nil, err
+		}
+
+		// Read the remaining rows of the fetched batch at once.
+		count := rows.fetched - rows.current
+		if count > n-batch.Len {
+			count = n - batch.Len
+		}
+
+		first := rows.current
+		for i, col := range batch.Columns {
+			if kinds[i] == kindValue {
+				// Values are converted row by row.
+				for row := 0; row < count; row++ {
+					rows.current = first + row
+					val, err := rows.value(i)
+					if err != nil {
+						return nil, err
+					}
+					if val == nil {
+						col.setNull(batch.Len + row)
+					}
+					col.Values = append(col.Values, val)
+				}
+				rows.current = first
+				continue
+			}
+
+			if err := rows.appendColumn(col, kinds[i], i, batch.Len, first, count); err != nil {
+				return nil, err
+			}
+		}
+
+		rows.current = first + count - 1
+		rows.rowNumber += uint64(count)
+		batch.Len += count
+	}
+
+	// Allocate the bitmaps of columns without null values.
+	for _, col := range batch.Columns {
+		for len(col.Nulls) < (batch.Len+63)/64 {
+			col.Nulls = append(col.Nulls, 0)
+		}
+	}
+
+	return batch, nil
+}
+
+// appendColumn appends count values of the column with the passed
+// index starting at row first of the fetched rows to col, which already
+// contains offset rows.
+func (rows *Rows) appendColumn(col *ColumnBatch, kind columnKind, index, offset, first, count int) error {
+	t := rows.colASEType[index]
+	stride := rows.colStrides[index]
+	data := rows.colData[index]
+	indicators := rows.colIndicators[index*rows.batchSize : index*rows.batchSize+rows.fetched]
+	copied := rows.colCopied[index*rows.batchSize : index*rows.batchSize+rows.fetched]
+
+	for row := first; row < first+count; row++ {
+		null := indicators[row] == -1
+		if null {
+			col.setNull(offset + row - first)
+		}
+
+		bs := data[row*stride : row*stride+stride]
+		switch kind {
+		case kindInt64:
+			var val int64
+			if !null {
+				var err error
+				if val, err = int64Value(t, bs); err != nil {
+					return err
+				}
+			}
+			col.Int64s = append(col.Int64s, val)
+		case kindFloat64:
+			var val float64
+			if !null {
+				var err error
+				if val, err = float64Value(t, bs); err != nil {
+					return err
+				}
+			}
+			col.Float64s = append(col.Float64s, val)
+		case kindString:
+			var val string
+			if !null {
+				bs = bs[:int(copied[row])]
+				if t == UNICHAR || t == UNIVARCHAR || t == UNITEXT {
+					val = decodeUTF16(bs)
+				} else {
+					val = string(bs)
+				}
+				val = rows.trim(index, val)
+			}
+			col.Strings = append(col.Strings, val)
+		}
+	}
+
+	return nil
+}
+
+// int64Value decodes the value of an integer or bit column from the
+// bound memory.
+func int64Value(t ASEType, bs []byte) (int64, error) {
+	if size := fixedByteSize(t); len(bs) < size {
+		return 0, fmt.Errorf("Received %d bytes for %s, expected %d", len(bs), t, size)
+	}
+
+	switch t {
+	case BIT, TINYINT:
+		return int64(bs[0]), nil
+	case SMALLINT:
+		return int64(int16(binary.LittleEndian.Uint16(bs))), nil
+	case USMALLINT, USHORT:
+		return int64(binary.LittleEndian.Uint16(bs)), nil
+	case INT:
+		return int64(int32(binary.LittleEndian.Uint32(bs))), nil
+	case UINT:
+		return int64(binary.LittleEndian.Uint32(bs)), nil
+	case BIGINT, LONG:
+		return int64(binary.LittleEndian.Uint64(bs)), nil
+	default:
+		return 0, fmt.Errorf("%s is not an integer type", t)
+	}
+}
+
+// float64Value decodes the value of a float or real column from the
+// bound memory.
+func float64Value(t ASEType, bs []byte) (float64, error) {
+	if size := fixedByteSize(t); len(bs) < size {
+		return 0, fmt.Errorf("Received %d bytes for %s, expected %d", len(bs), t, size)
+	}
+
+	switch t {
+	case REAL:
+		return float64(math.Float32frombits(binary.LittleEndian.Uint32(bs))), nil
+	case FLOAT:
+		return math.Float64frombits(binary.LittleEndian.Uint64(bs)), nil
+	default:
+		return 0, fmt.Errorf("%s is not a float type", t)
+	}
+}
@@ -0,0 +1,236 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"database/sql/driver"
+	"io"
+	"math"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/SAP/go-dblib/asetypes"
+)
+
+func TestInt64Value(t *testing.T) {
+	cases := map[ASEType]struct {
+		bs    []byte
+		value int64
+	}{
+		BIT:       {[]byte{0x1}, 1},
+		TINYINT:   {le(uint8(255)), 255},
+		SMALLINT:  {le(int16(-300)), -300},
+		USMALLINT: {le(uint16(65535)), 65535},
+		USHORT:    {le(uint16(42)), 42},
+		INT:       {le(int32(math.MinInt32)), math.MinInt32},
+		UINT:      {le(uint32(math.MaxUint32)), math.MaxUint32},
+		BIGINT:    {le(int64(math.MinInt64)), math.MinInt64},
+		LONG:      {le(int64(-5)), -5},
+	}
+
+	for typ, cas := range cases {
+		t.Run(typ.String(), func(t *testing.T) {
+			if kind := columnKindOf(typ); kind != kindInt64 {
+				t.Errorf("Expected kind %d, got %d", kindInt64, kind)
+			}
+
+			value, err := int64Value(typ, cas.bs)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if value != cas.value {
+				t.Errorf("Expected %d, got %d", cas.value, value)
+			}
+		})
+	}
+}
+
+func TestFloat64Value(t *testing.T) {
+	cases := map[ASEType]struct {
+		bs    []byte
+		value float64
+	}{
+		REAL:  {le(float32(-2.25)), -2.25},
+		FLOAT: {le(float64(1.5)), 1.5},
+	}
+
+	for typ, cas := range cases {
+		t.Run(typ.String(), func(t *testing.T) {
+			if kind := columnKindOf(typ); kind != kindFloat64 {
+				t.Errorf("Expected kind %d, got %d", kindFloat64, kind)
+			}
+
+			value, err := float64Value(typ, cas.bs)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if value != cas.value {
+				t.Errorf("Expected %g, got %g", cas.value, value)
+			}
+		})
+	}
+}
+
+func TestInt64ValueShort(t *testing.T) {
+	if _, err := int64Value(INT, []byte{0x1, 0x2}); err == nil {
+		t.Errorf("Expected error for short value")
+	}
+
+	if _, err := float64Value(FLOAT, []byte{0x1}); err == nil {
+		t.Errorf("Expected error for short value")
+	}
+}
+
+func TestColumnKindOf(t *testing.T) {
+	cases := map[ASEType]columnKind{
+		CHAR:       kindString,
+		VARCHAR:    kindString,
+		UNIVARCHAR: kindString,
+		DECIMAL:    kindValue,
+		UBIGINT:    kindValue,
+		DATETIME:   kindValue,
+		BINARY:     kindValue,
+	}
+
+	for typ, kind := range cases {
+		if got := columnKindOf(typ); got != kind {
+			t.Errorf("%s: expected kind %d, got %d", typ, kind, got)
+		}
+	}
+}
+
+func TestColumnBatchNulls(t *testing.T) {
+	col := &ColumnBatch{}
+	for _, row := range []int{0, 63, 64, 130} {
+		col.setNull(row)
+	}
+
+	if len(col.Nulls) != 3 {
+		t.Fatalf("Expected bitmap of 3 words, got %d", len(col.Nulls))
+	}
+
+	for row := 0; row < 192; row++ {
+		expected := row == 0 || row == 63 || row == 64 || row == 130
+		if col.IsNull(row) != expected {
+			t.Errorf("Row %d: expected null %t, got %t", row, expected, col.IsNull(row))
+		}
+	}
+}
+
+func TestRowsNextBatch(t *testing.T) {
+	values := make([][]fakeValue, 7)
+	for i := range values {
+		values[i] = []fakeValue{
+			{data: le(int32(i))},
+			{data: []byte{byte('a' + i)}},
+			{data: csDecimal(5, 0, false, byte(i))},
+		}
+	}
+	values[2][0] = fakeValue{null: true}
+	values[4][1] = fakeValue{null: true}
+	values[5][2] = fakeValue{null: true}
+
+	cases := map[string]struct {
+		fetchSize int
+		batchSize int
+		lens      []int
+	}{
+		"fetched row by row":            {1, 4, []int{4, 3}},
+		"batches across fetched rows":   {3, 4, []int{4, 3}},
+		"batches within fetched rows":   {5, 2, []int{2, 2, 2, 1}},
+		"batches equal to fetched rows": {3, 3, []int{3, 3, 1}},
+		"single batch":                  {7, 10, []int{7}},
+	}
+
+	for title, cas := range cases {
+		t.Run(title, func(t *testing.T) {
+			rows := fakeRows([]ASEType{INT, VARCHAR, DECIMAL}, []int{4, 8, 35}, cas.fetchSize, values)
+
+			row := 0
+			for _, length := range cas.lens {
+				batch, err := rows.NextBatch(cas.batchSize)
+				if err != nil {
+					t.Fatalf("Row %d: unexpected error: %v", row, err)
+				}
+
+				if batch.Len != length {
+					t.Fatalf("Row %d: expected batch of %d rows, received %d", row, length, batch.Len)
+				}
+
+				ints, strs, decs := batch.Columns[0], batch.Columns[1], batch.Columns[2]
+				if len(ints.Int64s) != length || len(strs.Strings) != length || len(decs.Values) != length {
+					t.Fatalf("Row %d: expected %d values per column, received %d, %d and %d",
+						row, length, len(ints.Int64s), len(strs.Strings), len(decs.Values))
+				}
+
+				for i := 0; i < length; i++ {
+					if null := row == 2; ints.IsNull(i) != null {
+						t.Errorf("Row %d: expected null %t for int column", row, null)
+					} else if !null && ints.Int64s[i] != int64(row) {
+						t.Errorf("Row %d: expected %d, received %d", row, row, ints.Int64s[i])
+					}
+
+					if null := row == 4; strs.IsNull(i) != null {
+						t.Errorf("Row %d: expected null %t for varchar column", row, null)
+					} else if expected := string(rune('a' + row)); !null && strs.Strings[i] != expected {
+						t.Errorf("Row %d: expected %q, received %q", row, expected, strs.Strings[i])
+					}
+
+					if null := row == 5; decs.IsNull(i) != null {
+						t.Errorf("Row %d: expected null %t for decimal column", row, null)
+					} else if !null {
+						dec, ok := decs.Values[i].(*asetypes.Decimal)
+						if expected := mustDecimal(5, 0, strconv.Itoa(row)); !ok || dec.String() != expected.String() {
+							t.Errorf("Row %d: expected %s, received %v", row, expected, decs.Values[i])
+						}
+					}
+
+					row++
+				}
+			}
+
+			if _, err := rows.NextBatch(cas.batchSize); err != io.EOF {
+				t.Errorf("Expected io.EOF, received %v", err)
+			}
+		})
+	}
+}
+
+func TestRowsNextBatchAfterNext(t *testing.T) {
+	values := make([][]fakeValue, 5)
+	for i := range values {
+		values[i] = []fakeValue{{data: le(int32(i))}}
+	}
+	rows := fakeRows([]ASEType{INT}, []int{4}, 2, values)
+
+	dest := make([]driver.Value, 1)
+	if err := rows.Next(dest); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	batch, err := rows.NextBatch(3)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if expected := []int64{1, 2, 3}; !reflect.DeepEqual(batch.Columns[0].Int64s, expected) {
+		t.Errorf("Expected %v, received %v", expected, batch.Columns[0].Int64s)
+	}
+
+	if err := rows.Next(dest); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if dest[0] != int32(4) {
+		t.Errorf("Expected 4, received %#v", dest[0])
+	}
+
+	if rows.rowNumber != 5 {
+		t.Errorf("Expected row number 5, received %d", rows.rowNumber)
+	}
+}
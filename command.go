@@ -19,11 +19,23 @@ import (
 // Command contains the C.command and indicates if that command is dynamic.
 type Command struct {
 	cmd       *C.CS_COMMAND
+	conn      *Connection
 	isDynamic bool
+
+	// pending are the rows of the last result returned by NextResult.
+	pending *Rows
+
+	// returnStatus is the return status of the last stored procedure
+	// executed by the command, set if hasReturnStatus is set.
+	returnStatus    int32
+	hasReturnStatus bool
 }
 
 // GenericExec is the central method through which SQL statements are
 // sent to ASE.
+//
+// If a statement fails the returned error is accompanied by a Result
+// with the results of the statements read, including the failed one.
 func (conn *Connection) GenericExec(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, driver.Result, error) {
 	if len(args) == 0 {
 		cmd, err := conn.NewCommand(ctx, query)
@@ -33,7 +45,7 @@ func (conn *Connection) GenericExec(ctx context.Context, query string, args []dr
 
 		rows, result, err := cmd.ConsumeResponse(ctx)
 		if err != nil {
-			return nil, nil, err
+			return nil, result, err
 		}
 
 		if rows != nil {
@@ -44,12 +56,40 @@ func (conn *Connection) GenericExec(ctx context.Context, query string, args []dr
 		return nil, result, nil
 	}
 
-	stmt, err := conn.prepare(ctx, query)
+	mode, err := conn.execModeFor(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch mode {
+	case ExecModeLanguage:
+		return conn.languageExec(ctx, query, args)
+	case ExecModeInterpolate:
+		return conn.interpolateExec(ctx, query, args)
+	}
+
+	if conn.stmtCache == nil {
+		stmt, err := conn.prepare(ctx, query, hasNamedArgs(args))
+		if err != nil {
+			// TODO
+			return nil, nil, err
+		}
+		defer stmt.Close()
+
+		for i := range args {
+			if err := stmt.CheckNamedValue(&args[i]); err != nil {
+				return nil, nil, fmt.Errorf("go-ase: error checking argument: %w", err)
+			}
+		}
+
+		return stmt.exec(ctx, args)
+	}
+
+	named := hasNamedArgs(args)
+	stmt, err := conn.cachedStatement(ctx, query, named)
 	if err != nil {
-		// TODO
 		return nil, nil, err
 	}
-	defer stmt.Close()
 
 	for i := range args {
 		if err := stmt.CheckNamedValue(&args[i]); err != nil {
@@ -57,7 +97,15 @@ func (conn *Connection) GenericExec(ctx context.Context, query string, args []dr
 		}
 	}
 
-	return stmt.exec(ctx, args)
+	rows, result, err := stmt.exec(ctx, args)
+	if err != nil {
+		// The statement may have been invalidated by a schema
+		// change, prepare it again on the next execution.
+		conn.invalidateFailedStatement(query, named)
+		return nil, result, err
+	}
+
+	return rows, result, nil
 }
 
 // NewCommand creates a new command.
@@ -85,17 +133,47 @@ func (cmd *Command) Drop() error {
 	return nil
 }
 
+// discardResults reads all results of the command and discards any
+// fetchable results.
+func (cmd *Command) discardResults() error {
+	for {
+		var resultType C.CS_INT
+		retval := C.ct_results(cmd.cmd, &resultType)
+		switch retval {
+		case C.CS_SUCCEED:
+		case C.CS_END_RESULTS:
+			return nil
+		default:
+			cmd.Cancel()
+			return makeError(retval, "Failed to read results")
+		}
+
+		switch resultType {
+		case C.CS_ROW_RESULT, C.CS_PARAM_RESULT, C.CS_STATUS_RESULT, C.CS_COMPUTE_RESULT, C.CS_CURSOR_RESULT:
+			retval = C.ct_cancel(nil, cmd.cmd, C.CS_CANCEL_CURRENT)
+			if retval != C.CS_SUCCEED {
+				return makeError(retval, "Failed to discard result")
+			}
+		case C.CS_CMD_FAIL:
+			cmd.Cancel()
+			return errors.New("Command failed")
+		}
+	}
+}
+
 // exec allocates, prepares and sends a command.
 //
 // The return values are the command structure, a function to deallocate
 // the command structure and an error, if any occurred.
 func (conn *Connection) exec(ctx context.Context, query string) (*Command, error) {
-	cmd := &Command{}
+	cmd := &Command{conn: conn}
 	retval := C.ct_cmd_alloc(conn.conn, &cmd.cmd)
 	if retval != C.CS_SUCCEED {
 		return nil, makeError(retval, "Failed to allocate command structure")
 	}
 
+	conn.resetMessages()
+
 	sql := C.CString(query)
 	defer C.free(unsafe.Pointer(sql))
 
@@ -107,6 +185,7 @@ func (conn *Connection) exec(ctx context.Context, query string) (*Command, error
 	}
 
 	// Send command to ASE
+	cmd.resetResults()
 	retval = C.ct_send(cmd.cmd)
 	if retval != C.CS_SUCCEED {
 		cmd.Drop()
@@ -116,9 +195,15 @@ func (conn *Connection) exec(ctx context.Context, query string) (*Command, error
 	return cmd, nil
 }
 
+// resetResults resets the results read for a previous execution of the
+// command, so that they are not reported for the next execution.
+func (cmd *Command) resetResults() {
+	cmd.returnStatus, cmd.hasReturnStatus = 0, false
+}
+
 // dynamic initializes a Command as a prepared statement.
 func (conn *Connection) dynamic(name string, query string) (*Command, error) {
-	cmd := &Command{}
+	cmd := &Command{conn: conn}
 	cmd.isDynamic = true
 	retval := C.ct_cmd_alloc(conn.conn, &cmd.cmd)
 	if retval != C.CS_SUCCEED {
@@ -155,6 +240,9 @@ func (conn *Connection) dynamic(name string, query string) (*Command, error) {
 // The third return value is a CS_INT, which may be the CS_RETCODE of
 // ct_results when the command failed or finished or the result type if
 // the result set requires further processing.
+//
+// When a statement of the command failed the command is cancelled and
+// a Result with the failed statement is returned alongside the error.
 func (cmd *Command) Response() (*Rows, *Result, C.CS_INT, error) {
 	var resultType C.CS_INT
 	retval := C.ct_results(cmd.cmd, &resultType)
@@ -167,7 +255,7 @@ func (cmd *Command) Response() (*Rows, *Result, C.CS_INT, error) {
 		return nil, nil, retval, io.EOF // no more responses available, quit
 	case C.CS_FAIL:
 		cmd.Cancel()
-		return nil, nil, retval, makeError(retval, "Command failed")
+		return nil, nil, retval, cmd.conn.commandError(makeError(retval, "Command failed"))
 	default:
 		cmd.Cancel()
 		return nil, nil, retval, makeError(retval, "Invalid return code")
@@ -175,9 +263,36 @@ func (cmd *Command) Response() (*Rows, *Result, C.CS_INT, error) {
 
 	switch resultType {
 	// fetchable results
-	case C.CS_COMPUTE_RESULT, C.CS_CURSOR_RESULT, C.CS_PARAM_RESULT:
+	case C.CS_COMPUTE_RESULT:
+		rows, err := newComputeRows(cmd)
+		if err != nil {
+			cmd.Cancel()
+			return nil, nil, C.CS_UNUSED, err
+		}
+
+		return rows, nil, C.CS_UNUSED, nil
+	case C.CS_STATUS_RESULT:
+		if cmd.conn.driverCtx.info.ReturnStatusRows {
+			rows, err := newRows(cmd)
+			if err != nil {
+				cmd.Cancel()
+				return nil, nil, C.CS_UNUSED, err
+			}
+
+			return rows, nil, C.CS_UNUSED, nil
+		}
+
+		status, err := cmd.fetchReturnStatus()
+		if err != nil {
+			cmd.Cancel()
+			return nil, nil, C.CS_UNUSED, err
+		}
+
+		cmd.returnStatus, cmd.hasReturnStatus = status, true
+		return nil, nil, C.CS_UNUSED, nil
+	case C.CS_CURSOR_RESULT, C.CS_PARAM_RESULT:
 		fallthrough
-	case C.CS_ROW_RESULT, C.CS_STATUS_RESULT:
+	case C.CS_ROW_RESULT:
 		rows, err := newRows(cmd)
 		if err != nil {
 			cmd.Cancel()
@@ -192,8 +307,15 @@ func (cmd *Command) Response() (*Rows, *Result, C.CS_INT, error) {
 
 	// other result types
 	case C.CS_CMD_FAIL:
+		// The failed statement is returned alongside the error, the
+		// transaction state must be read before cancelling.
+		state := cmd.transactionState()
 		cmd.Cancel()
-		return nil, nil, C.CS_UNUSED, makeError(retval, "Command failed, cancelled")
+
+		return nil, &Result{
+			rowsAffected: -1,
+			statements:   []StatementResult{{RowsAffected: -1, TransactionState: state, Failed: true}},
+		}, C.CS_UNUSED, cmd.conn.commandError(makeError(retval, "Command failed, cancelled"))
 	case C.CS_CMD_DONE:
 		var rowsAffected C.CS_INT
 		retval := C.ct_res_info(cmd.cmd, C.CS_ROW_COUNT, unsafe.Pointer(&rowsAffected),
@@ -203,7 +325,12 @@ func (cmd *Command) Response() (*Rows, *Result, C.CS_INT, error) {
 			return nil, nil, C.CS_UNUSED, makeError(retval, "Failed to read affected rows")
 		}
 
-		return nil, &Result{int64(rowsAffected)}, C.CS_UNUSED, nil
+		state := cmd.transactionState()
+
+		return nil, &Result{
+			rowsAffected: int64(rowsAffected),
+			statements:   []StatementResult{{RowsAffected: int64(rowsAffected), TransactionState: state}},
+		}, C.CS_UNUSED, nil
 	case C.CS_CMD_SUCCEED:
 		return nil, nil, C.CS_UNUSED, nil
 
@@ -215,6 +342,18 @@ func (cmd *Command) Response() (*Rows, *Result, C.CS_INT, error) {
 
 // ConsumeResponse is a wrapper around .Response that guarantees that
 // all results have been read.
+//
+// The returned Result contains the number of rows affected by the last
+// statement and the results of all statements read.
+//
+// Reading stops at the first result set, which is returned with the
+// results of the statements before it. The results of the statements
+// following the result set are only collected by Rows.consumeRemaining,
+// which discards the result sets.
+//
+// Reading also stops at the first failed statement, as the command is
+// cancelled. The results of the statements read including the failed
+// statement are returned alongside the error.
 func (cmd *Command) ConsumeResponse(ctx context.Context) (*Rows, *Result, error) {
 	var resResult *Result
 outer:
@@ -224,22 +363,69 @@ outer:
 			break outer
 		default:
 			rows, result, _, err := cmd.Response()
+			resResult = appendResult(resResult, result)
 			if err != nil {
 				if errors.Is(err, io.EOF) {
 					break outer
 				}
-				return nil, nil, fmt.Errorf("go-ase: received error reading results: %w", err)
-			}
-
-			if result != nil {
-				resResult = result
+				return nil, cmd.withReturnStatus(resResult), fmt.Errorf("go-ase: received error reading results: %w", err)
 			}
 
 			if rows != nil {
-				return rows, result, nil
+				return rows, cmd.withReturnStatus(resResult), nil
 			}
 		}
 	}
 
-	return nil, resResult, nil
+	return nil, cmd.withReturnStatus(resResult), nil
+}
+
+// consumeRemaining discards the rows and all following result sets of
+// the command and returns result with the results of the statements
+// read appended.
+func (rows *Rows) consumeRemaining(ctx context.Context, result *Result) (*Result, error) {
+	cmd := rows.cmd
+	if cmd == nil {
+		return result, nil
+	}
+
+	// Only discard the current result set.
+	rows.inResults = true
+	if err := rows.Close(); err != nil {
+		return result, err
+	}
+
+	for {
+		next, nextResult, err := cmd.ConsumeResponse(ctx)
+		result = appendResult(result, nextResult)
+		if err != nil {
+			return result, err
+		}
+
+		if next == nil {
+			break
+		}
+
+		next.inResults = true
+		if err := next.Close(); err != nil {
+			return result, err
+		}
+	}
+
+	if !cmd.isDynamic {
+		if err := cmd.Drop(); err != nil {
+			return result, fmt.Errorf("Error dropping command: %w", err)
+		}
+	}
+
+	return cmd.withReturnStatus(result), nil
+}
+
+// withReturnStatus sets the return status read by the command on
+// result.
+func (cmd *Command) withReturnStatus(result *Result) *Result {
+	if result != nil && cmd.hasReturnStatus {
+		result.returnStatus, result.hasReturnStatus = cmd.returnStatus, true
+	}
+	return result
 }
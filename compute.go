@@ -0,0 +1,174 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+//#include "ctlib.h"
+import "C"
+import (
+	"fmt"
+	"unsafe"
+)
+
+// ComputeOp is the aggregate operator of a column of a compute row.
+type ComputeOp int
+
+// Aggregate operators of CS_COMP_OP.
+const (
+	ComputeSum   ComputeOp = C.CS_OP_SUM
+	ComputeAvg   ComputeOp = C.CS_OP_AVG
+	ComputeCount ComputeOp = C.CS_OP_COUNT
+	ComputeMin   ComputeOp = C.CS_OP_MIN
+	ComputeMax   ComputeOp = C.CS_OP_MAX
+)
+
+func (op ComputeOp) String() string {
+	switch op {
+	case ComputeSum:
+		return "sum"
+	case ComputeAvg:
+		return "avg"
+	case ComputeCount:
+		return "count"
+	case ComputeMin:
+		return "min"
+	case ComputeMax:
+		return "max"
+	default:
+		return fmt.Sprintf("ComputeOp(%d)", int(op))
+	}
+}
+
+// ComputeColumn describes a column of a compute row.
+type ComputeColumn struct {
+	// Op is the aggregate operator of the column.
+	Op ComputeOp
+	// Column is the number of the column in the select list the
+	// aggregate is computed over, starting at 1.
+	Column int
+}
+
+// ComputeInfo describes the rows of a compute result, which are
+// returned for the compute clauses of a query, e.g.
+// `compute sum(amount) by region`.
+type ComputeInfo struct {
+	// ID identifies the compute clause within the query, starting at 1.
+	ID int
+	// Columns describes the columns of the compute rows in order.
+	Columns []ComputeColumn
+	// ByList contains the numbers of the columns in the select list of
+	// the by clause, starting at 1. ByList is empty for compute clauses
+	// without by clause.
+	ByList []int
+}
+
+// byListSize is the number of columns of the by clause of a compute
+// clause read initially. Longer by lists are read again with a buffer
+// of the size reported by Client-Library.
+const byListSize = 16
+
+// newComputeInfo reads the compute information of the current compute
+// result of cmd with the passed number of columns.
+func newComputeInfo(cmd *Command, numCols int) (*ComputeInfo, error) {
+	info := &ComputeInfo{Columns: make([]ComputeColumn, numCols)}
+
+	var id C.CS_INT
+	retval := C.ct_compute_info(cmd.cmd, C.CS_COMP_ID, C.CS_UNUSED, unsafe.Pointer(&id), C.sizeof_CS_INT, nil)
+	if retval != C.CS_SUCCEED {
+		return nil, makeError(retval, "Failed to read compute id")
+	}
+	info.ID = int(id)
+
+	for i := range info.Columns {
+		var op, colID C.CS_INT
+
+		retval := C.ct_compute_info(cmd.cmd, C.CS_COMP_OP, C.CS_INT(i+1), unsafe.Pointer(&op), C.sizeof_CS_INT, nil)
+		if retval != C.CS_SUCCEED {
+			return nil, makeError(retval, "Failed to read operator of compute column %d", i+1)
+		}
+
+		retval = C.ct_compute_info(cmd.cmd, C.CS_COMP_COLID, C.CS_INT(i+1), unsafe.Pointer(&colID), C.sizeof_CS_INT, nil)
+		if retval != C.CS_SUCCEED {
+			return nil, makeError(retval, "Failed to read select list column of compute column %d", i+1)
+		}
+
+		info.Columns[i] = ComputeColumn{Op: ComputeOp(op), Column: int(colID)}
+	}
+
+	byList, err := readByList(func(buf []int16) (int, error) {
+		var outlen C.CS_INT
+		retval := C.ct_compute_info(cmd.cmd, C.CS_COMP_BYLIST, C.CS_UNUSED, unsafe.Pointer(&buf[0]),
+			C.CS_INT(len(buf)*C.sizeof_CS_SMALLINT), &outlen)
+		if retval != C.CS_SUCCEED {
+			return int(outlen), makeError(retval, "Failed to read by list of compute result")
+		}
+		return int(outlen), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	info.ByList = byList
+
+	return info, nil
+}
+
+// ComputeInfo returns the description of the compute clause the rows
+// were returned for or nil if the rows are no compute result.
+//
+// Compute rows follow the rows of the query they are computed for and
+// are only returned by Command.NextResult and Command.Response. Rows
+// returned through database/sql only contain the first result set and
+// do not implement driver.RowsNextResultSet, hence compute rows are
+// discarded with the remaining results when the rows are closed.
+func (rows *Rows) ComputeInfo() *ComputeInfo {
+	return rows.computeInfo
+}
+
+// newComputeRows binds the columns of the current compute result of cmd
+// and reads its compute information.
+func newComputeRows(cmd *Command) (*Rows, error) {
+	rows, err := newRows(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	rows.computeInfo, err = newComputeInfo(cmd, rows.numCols)
+	if err != nil {
+		rows.free()
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+// readByList reads the by list of a compute clause with read, which
+// reads the column numbers into the passed buffer and returns the length
+// of the by list in bytes. If the by list does not fit into the buffer
+// read is called again with a buffer of the returned length.
+func readByList(read func(buf []int16) (int, error)) ([]int, error) {
+	buf := make([]int16, byListSize)
+	outlen, err := read(buf)
+	if err != nil {
+		if outlen <= len(buf)*C.sizeof_CS_SMALLINT {
+			return nil, err
+		}
+
+		buf = make([]int16, (outlen+C.sizeof_CS_SMALLINT-1)/C.sizeof_CS_SMALLINT)
+		if outlen, err = read(buf); err != nil {
+			return nil, err
+		}
+	}
+
+	if outlen > len(buf)*C.sizeof_CS_SMALLINT {
+		return nil, fmt.Errorf("Received by list of %d bytes for a buffer of %d bytes",
+			outlen, len(buf)*C.sizeof_CS_SMALLINT)
+	}
+
+	byList := make([]int, outlen/C.sizeof_CS_SMALLINT)
+	for i := range byList {
+		byList[i] = int(buf[i])
+	}
+
+	return byList, nil
+}
@@ -0,0 +1,93 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestComputeOpString(t *testing.T) {
+	cases := map[ComputeOp]string{
+		ComputeSum:   "sum",
+		ComputeAvg:   "avg",
+		ComputeCount: "count",
+		ComputeMin:   "min",
+		ComputeMax:   "max",
+		ComputeOp(1): "ComputeOp(1)",
+	}
+
+	for op, expected := range cases {
+		if s := op.String(); s != expected {
+			t.Errorf("Expected %q, got %q", expected, s)
+		}
+	}
+}
+
+func TestReadByList(t *testing.T) {
+	cases := map[string]struct {
+		byList []int16
+	}{
+		"empty":          {[]int16{}},
+		"single":         {[]int16{2}},
+		"initial buffer": {[]int16{1, 3, 5, 7, 9, 11, 13, 15, 17, 19, 21, 23, 25, 27, 29, 31}},
+		"exceeds buffer": {[]int16{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20}},
+	}
+
+	for title, cas := range cases {
+		t.Run(title, func(t *testing.T) {
+			calls := 0
+			byList, err := readByList(func(buf []int16) (int, error) {
+				calls++
+				outlen := len(cas.byList) * 2
+				if len(buf) < len(cas.byList) {
+					return outlen, errors.New("buffer too small")
+				}
+				copy(buf, cas.byList)
+				return outlen, nil
+			})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if len(byList) != len(cas.byList) {
+				t.Fatalf("Expected %d columns, received %d", len(cas.byList), len(byList))
+			}
+
+			for i := range byList {
+				if byList[i] != int(cas.byList[i]) {
+					t.Errorf("Column %d: expected %d, received %d", i, cas.byList[i], byList[i])
+				}
+			}
+
+			expected := 1
+			if len(cas.byList) > byListSize {
+				expected = 2
+			}
+			if calls != expected {
+				t.Errorf("Expected %d reads, received %d", expected, calls)
+			}
+		})
+	}
+}
+
+func TestReadByListError(t *testing.T) {
+	failure := errors.New("failure")
+
+	_, err := readByList(func(buf []int16) (int, error) {
+		return 0, failure
+	})
+	if !errors.Is(err, failure) {
+		t.Errorf("Expected %v, received %v", failure, err)
+	}
+
+	// Retrying with the reported length fails as well.
+	_, err = readByList(func(buf []int16) (int, error) {
+		return len(buf)*2 + 2, failure
+	})
+	if !errors.Is(err, failure) {
+		t.Errorf("Expected %v, received %v", failure, err)
+	}
+}
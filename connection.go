@@ -12,6 +12,8 @@ import (
 	"database/sql/driver"
 	"fmt"
 	"io"
+	"math/big"
+	"sync"
 	"unsafe"
 
 	"github.com/SAP/go-dblib"
@@ -31,10 +33,40 @@ var (
 	_ driver.NamedValueChecker  = (*Connection)(nil)
 )
 
+// connections maps the CS_CONNECTION of open connections to their
+// Connection to pass server messages to the connection they were
+// received on.
+var connections sync.Map
+
 // Connection implements the driver.Conn interface.
 type Connection struct {
 	conn      *C.CS_CONNECTION
 	driverCtx *csContext
+
+	// errorMessages records the server messages with a severity
+	// above informational received since the last command was sent.
+	errorMessages []ServerMessage
+	messagesLock  sync.Mutex
+
+	// native converts result values into idiomatic Go types, nil if
+	// native types are not enabled.
+	native *nativeConverter
+
+	// userTypes maps the usertypes of user-defined datatypes to the
+	// converters registered with RegisterUserType.
+	userTypes     map[int]TypeConverter
+	userTypesLock sync.RWMutex
+
+	// stmtCache caches the statements prepared by GenericExec, nil if
+	// the cache is disabled.
+	stmtCache *stmtCache
+	// execMode is the default ExecMode of queries with arguments.
+	execMode ExecMode
+	// savepointSeq numbers the savepoints set by BeginNested.
+	savepointSeq int
+	// locators contains the locators allocated for the connection
+	// which were not closed yet.
+	locators map[*Locator]struct{}
 }
 
 // NewConnection allocates a new connection based on the
@@ -50,18 +82,32 @@ func NewConnection(driverCtx *csContext, info *Info) (*Connection, error) {
 		}
 	}
 
+	native, err := newNativeConverter(info)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to configure native types: %w", err)
+	}
+
+	execMode, err := parseExecMode(info.ExecMode)
+	if err != nil {
+		return nil, err
+	}
+
 	if err := driverCtx.newConn(); err != nil {
 		return nil, fmt.Errorf("Failed to ensure context: %w", err)
 	}
 
 	conn := &Connection{
 		driverCtx: driverCtx,
+		native:    native,
+		stmtCache: newStmtCache(info.StatementCacheSize),
+		execMode:  execMode,
 	}
 
 	if retval := C.ct_con_alloc(driverCtx.ctx, &conn.conn); retval != C.CS_SUCCEED {
 		conn.Close()
 		return nil, makeError(retval, "C.ct_con_alloc failed")
 	}
+	connections.Store(conn.conn, conn)
 
 	// Set password encryption
 	cTrue := C.CS_TRUE
@@ -135,6 +181,15 @@ func NewConnection(driverCtx *csContext, info *Info) (*Connection, error) {
 		return nil, makeError(retval, "C.ct_connect failed")
 	}
 
+	// Streamed values would otherwise be truncated to the default
+	// @@textsize.
+	if info.LOBStreaming {
+		if err := conn.setTextSize(maxTextSize); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+
 	// Set database
 	if info.Database != "" {
 		if _, err := conn.Exec("use "+info.Database, nil); err != nil {
@@ -143,6 +198,11 @@ func NewConnection(driverCtx *csContext, info *Info) (*Connection, error) {
 		}
 	}
 
+	if err := conn.RefreshUserTypes(context.Background()); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("Failed to look up user-defined datatypes: %w", err)
+	}
+
 	return conn, nil
 }
 
@@ -153,6 +213,18 @@ func (conn *Connection) Close() error {
 	// connection counter and potentially deallocate the context.
 	defer conn.driverCtx.dropConn()
 
+	if conn.stmtCache != nil {
+		for _, stmt := range conn.stmtCache.clear() {
+			stmt.Close()
+		}
+	}
+
+	for loc := range conn.locators {
+		loc.Close()
+	}
+
+	connections.Delete(conn.conn)
+
 	retval := C.ct_close(conn.conn, C.CS_UNUSED)
 	if retval != C.CS_SUCCEED {
 		return makeError(retval, "C.ct_close failed, connection has results pending")
@@ -167,6 +239,43 @@ func (conn *Connection) Close() error {
 	return nil
 }
 
+// recvServerMessage records server messages reporting errors.
+func (conn *Connection) recvServerMessage(csMsg *C.CS_SERVERMSG) {
+	msg := newServerMessage(csMsg)
+	if msg.Severity <= 10 {
+		return
+	}
+
+	conn.messagesLock.Lock()
+	defer conn.messagesLock.Unlock()
+
+	conn.errorMessages = append(conn.errorMessages, *msg)
+}
+
+// resetMessages discards the recorded server messages. It is called
+// before a new command is sent.
+func (conn *Connection) resetMessages() {
+	conn.messagesLock.Lock()
+	defer conn.messagesLock.Unlock()
+
+	conn.errorMessages = nil
+}
+
+// commandError returns err with the sentinel error matching the server
+// messages received for the failed command, if any.
+func (conn *Connection) commandError(err error) error {
+	conn.messagesLock.Lock()
+	defer conn.messagesLock.Unlock()
+
+	for _, msg := range conn.errorMessages {
+		if msg.MsgNumber == msgNumberRowChanged {
+			return fmt.Errorf("%w: %s: %v", ErrRowChanged, msg.Text, err)
+		}
+	}
+
+	return err
+}
+
 // Ping implements the driver.Pinger interface.
 func (conn *Connection) Ping(ctx context.Context) error {
 	rows, err := conn.QueryContext(ctx, "SELECT 'PING'", nil)
@@ -198,8 +307,27 @@ func (conn *Connection) Exec(query string, args []driver.Value) (driver.Result,
 
 // ExecContext implements the driver.ExecerContext interface.
 func (conn *Connection) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
-	_, result, err := conn.GenericExec(ctx, query, args)
-	return result, err
+	rows, result, err := conn.GenericExec(ctx, query, args)
+	if err != nil {
+		return nil, err
+	}
+
+	// Discard result sets so that @@identity can be read and collect
+	// the results of the statements following them.
+	if r, ok := asRows(rows); ok {
+		res, _ := asResult(result)
+		if res, err = r.consumeRemaining(ctx, res); err != nil {
+			return nil, err
+		}
+		result = res
+	}
+
+	if !conn.driverCtx.info.LastInsertID {
+		return result, nil
+	}
+
+	inserts, executes := identityStatements(query)
+	return conn.withLastInsertID(ctx, inserts, executes, result)
 }
 
 // Query implements the driver.Queryer interface.
@@ -215,6 +343,32 @@ func (conn *Connection) QueryContext(ctx context.Context, query string, args []d
 
 // CheckNamedValue implements the driver.NamedValueChecker interface.
 func (conn *Connection) CheckNamedValue(nv *driver.NamedValue) error {
+	if valuer, ok := nv.Value.(driver.Valuer); ok {
+		v, err := valuer.Value()
+		if err != nil {
+			return err
+		}
+		nv.Value = v
+	}
+
+	// Locators are passed as-is and checked against the parameter type
+	// by the statement.
+	if _, ok := nv.Value.(*Locator); ok {
+		return nil
+	}
+
+	// Rationals are converted with the precision and scale of the
+	// parameter by the statement.
+	if _, ok := nv.Value.(*big.Rat); ok {
+		return nil
+	}
+
+	// Values of types with a registered converter are passed to the
+	// converter as-is by the statement.
+	if conn.hasConverterFor(nv.Value) {
+		return nil
+	}
+
 	v, err := asetypes.DefaultValueConverter.ConvertValue(nv.Value)
 	if err != nil {
 		return err
@@ -103,7 +103,7 @@ func (context *csContext) drop() error {
 	}
 
 	context.ctx = nil
-	return nil
+	return freeConvertCtx()
 }
 
 // applyDSN applies the relevant connection properties of a DSN to the
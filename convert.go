@@ -0,0 +1,140 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+//#include <stdlib.h>
+//#include "ctlib.h"
+import "C"
+import (
+	"fmt"
+	"sync"
+	"unsafe"
+)
+
+var (
+	// convertCtx is the context used by Convert. It is allocated on
+	// the first call and released with the last driver context.
+	convertCtx     *C.CS_CONTEXT
+	convertCtxLock sync.Mutex
+)
+
+// Convert converts the bytes of a value of the type fromType into the
+// bytes of a value of the type toType using cs_convert.
+//
+// The bytes are in the representation of Client-Library, e.g. a CS_INT
+// in host byte order or the characters of a CS_CHAR without
+// terminator.
+func Convert(value []byte, fromType, toType ASEType) ([]byte, error) {
+	convertCtxLock.Lock()
+	defer convertCtxLock.Unlock()
+
+	if convertCtx == nil {
+		if retval := C.cs_ctx_alloc(C.CS_CURRENT_VERSION, &convertCtx); retval != C.CS_SUCCEED {
+			convertCtx = nil
+			return nil, makeError(retval, "C.cs_ctx_alloc failed")
+		}
+	}
+
+	return csConvert(convertCtx, value, fromType, toType)
+}
+
+// freeConvertCtx releases the context used by Convert. A new context is
+// allocated by the next call to Convert.
+func freeConvertCtx() error {
+	convertCtxLock.Lock()
+	defer convertCtxLock.Unlock()
+
+	if convertCtx == nil {
+		return nil
+	}
+
+	if retval := C.cs_ctx_drop(convertCtx); retval != C.CS_SUCCEED {
+		return makeError(retval, "C.cs_ctx_drop failed for conversion context")
+	}
+
+	convertCtx = nil
+	return nil
+}
+
+// willConvert returns true if Client-Library supports converting
+// values of the type fromType into toType.
+func willConvert(ctx *C.CS_CONTEXT, fromType, toType ASEType) bool {
+	var result C.CS_BOOL
+	retval := C.cs_will_convert(ctx, (C.CS_INT)(fromType), (C.CS_INT)(toType), &result)
+	return retval == C.CS_SUCCEED && result == C.CS_TRUE
+}
+
+// fallbackType returns the type values of the type t are converted into
+// if the driver does not support t or 0 if the values cannot be
+// converted.
+func (conn *Connection) fallbackType(t ASEType) ASEType {
+	if conn == nil {
+		return 0
+	}
+
+	for _, target := range []ASEType{CHAR, BINARY} {
+		if willConvert(conn.driverCtx.ctx, t, target) {
+			return target
+		}
+	}
+	return 0
+}
+
+// convertLength returns the size of the buffer for the result of a
+// conversion of value into toType.
+func convertLength(value []byte, toType ASEType) int {
+	switch toType {
+	case CHAR, VARCHAR, LONGCHAR, TEXT, BINARY, VARBINARY, LONGBINARY, IMAGE:
+		// Binary values are converted into two hexadecimal
+		// characters per byte, the remaining types fit into a
+		// fixed overhead.
+		return 2*len(value) + 64
+	case DECIMAL, NUMERIC:
+		return C.sizeof_CS_DECIMAL
+	}
+
+	if n := fixedByteSize(toType); n > 0 {
+		return n
+	}
+	return 2*len(value) + 64
+}
+
+// csConvert converts value from fromType into toType with cs_convert.
+func csConvert(ctx *C.CS_CONTEXT, value []byte, fromType, toType ASEType) ([]byte, error) {
+	if !willConvert(ctx, fromType, toType) {
+		return nil, fmt.Errorf("Client-Library cannot convert type %d to type %d", fromType, toType)
+	}
+
+	srcFmt := (*C.CS_DATAFMT)(C.calloc(1, C.sizeof_CS_DATAFMT))
+	defer C.free(unsafe.Pointer(srcFmt))
+	srcFmt.datatype = (C.CS_INT)(fromType)
+	srcFmt.maxlength = (C.CS_INT)(len(value))
+	srcFmt.format = C.CS_FMT_UNUSED
+
+	destLength := convertLength(value, toType)
+	destFmt := (*C.CS_DATAFMT)(C.calloc(1, C.sizeof_CS_DATAFMT))
+	defer C.free(unsafe.Pointer(destFmt))
+	destFmt.datatype = (C.CS_INT)(toType)
+	destFmt.maxlength = (C.CS_INT)(destLength)
+	destFmt.format = C.CS_FMT_UNUSED
+	destFmt.precision = C.CS_SRC_VALUE
+	destFmt.scale = C.CS_SRC_VALUE
+
+	// cs_convert does not accept a nil source, hence at least one byte
+	// is allocated.
+	src := C.calloc((C.ulong)(len(value)+1), C.sizeof_CS_BYTE)
+	defer C.free(src)
+	copy(unsafe.Slice((*byte)(src), len(value)), value)
+
+	dest := C.calloc((C.ulong)(destLength), C.sizeof_CS_BYTE)
+	defer C.free(dest)
+
+	var outlen C.CS_INT
+	if retval := C.cs_convert(ctx, srcFmt, src, destFmt, dest, &outlen); retval != C.CS_SUCCEED {
+		return nil, makeError(retval, "C.cs_convert from type %d to type %d failed", fromType, toType)
+	}
+
+	return C.GoBytes(dest, outlen), nil
+}
@@ -0,0 +1,93 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// +build integration
+
+package ase
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestConvert(t *testing.T) {
+	cases := map[string]struct {
+		value    []byte
+		from, to ASEType
+		expected []byte
+	}{
+		"int to char":    {le(int32(-42)), INT, CHAR, []byte("-42")},
+		"char to int":    {[]byte("42"), CHAR, INT, le(int32(42))},
+		"char to bigint": {[]byte("9007199254740993"), CHAR, BIGINT, le(int64(9007199254740993))},
+		"binary to char": {[]byte{0xca, 0xfe}, BINARY, CHAR, []byte("cafe")},
+		"char to binary": {[]byte("cafe"), CHAR, BINARY, []byte{0xca, 0xfe}},
+		"empty char":     {[]byte{}, CHAR, CHAR, []byte{}},
+	}
+
+	for title, cas := range cases {
+		t.Run(title, func(t *testing.T) {
+			recv, err := Convert(cas.value, cas.from, cas.to)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if !bytes.Equal(recv, cas.expected) {
+				t.Errorf("Expected %#v, received %#v", cas.expected, recv)
+			}
+		})
+	}
+}
+
+func TestConvertUnsupported(t *testing.T) {
+	if _, err := Convert([]byte("abc"), CHAR, ASEType(250)); err == nil {
+		t.Errorf("Expected error converting into unknown type")
+	}
+}
+
+func TestWillConvert(t *testing.T) {
+	// Allocate the conversion context.
+	if _, err := Convert(le(int32(1)), INT, CHAR); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	convertCtxLock.Lock()
+	defer convertCtxLock.Unlock()
+
+	if !willConvert(convertCtx, INT, CHAR) {
+		t.Errorf("Expected int to be convertible to char")
+	}
+
+	if willConvert(convertCtx, INT, ASEType(250)) {
+		t.Errorf("Expected int not to be convertible to unknown type")
+	}
+
+	conn := &Connection{driverCtx: &csContext{ctx: convertCtx}}
+
+	if recv := conn.fallbackType(INT); recv != CHAR {
+		t.Errorf("Expected fallback type %s, received %s", CHAR, recv)
+	}
+
+	if recv := conn.fallbackType(ASEType(250)); recv != 0 {
+		t.Errorf("Expected no fallback type, received %s", recv)
+	}
+}
+
+func TestFreeConvertCtx(t *testing.T) {
+	if _, err := Convert(le(int32(1)), INT, CHAR); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if err := freeConvertCtx(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if convertCtx != nil {
+		t.Errorf("Expected conversion context to be released")
+	}
+
+	// Convert allocates a new context.
+	if _, err := Convert(le(int32(1)), INT, CHAR); err != nil {
+		t.Errorf("Unexpected error after releasing context: %v", err)
+	}
+}
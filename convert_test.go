@@ -0,0 +1,57 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import "testing"
+
+func TestConvertLength(t *testing.T) {
+	cases := map[string]struct {
+		value    []byte
+		toType   ASEType
+		expected int
+	}{
+		"char":            {[]byte("abc"), CHAR, 2*3 + 64},
+		"varchar empty":   {nil, VARCHAR, 64},
+		"longchar":        {make([]byte, 100), LONGCHAR, 2*100 + 64},
+		"text":            {make([]byte, 10), TEXT, 2*10 + 64},
+		"binary":          {[]byte{0x1, 0x2}, BINARY, 2*2 + 64},
+		"varbinary":       {[]byte{0x1}, VARBINARY, 2 + 64},
+		"longbinary":      {make([]byte, 8), LONGBINARY, 2*8 + 64},
+		"image":           {make([]byte, 8), IMAGE, 2*8 + 64},
+		"int":             {[]byte("123"), INT, 4},
+		"bigint":          {[]byte("123"), BIGINT, 8},
+		"float":           {[]byte("1.5"), FLOAT, 8},
+		"tinyint":         {[]byte("1"), TINYINT, 1},
+		"datetime":        {[]byte("2020-01-01"), DATETIME, 8},
+		"variable length": {[]byte("abcd"), UNITEXT, 2*4 + 64},
+	}
+
+	for title, cas := range cases {
+		t.Run(title, func(t *testing.T) {
+			if recv := convertLength(cas.value, cas.toType); recv != cas.expected {
+				t.Errorf("Expected %d, received %d", cas.expected, recv)
+			}
+		})
+	}
+}
+
+func TestConvertLengthDecimal(t *testing.T) {
+	// The size of a CS_DECIMAL: precision, scale and CS_MAX_NUMLEN
+	// bytes.
+	const sizeDecimal = 2 + 33
+
+	for _, toType := range []ASEType{DECIMAL, NUMERIC} {
+		if recv := convertLength([]byte("12.5"), toType); recv != sizeDecimal {
+			t.Errorf("%s: expected %d, received %d", toType, sizeDecimal, recv)
+		}
+	}
+}
+
+func TestFallbackTypeWithoutConnection(t *testing.T) {
+	var conn *Connection
+	if recv := conn.fallbackType(BIGDATETIME); recv != 0 {
+		t.Errorf("Expected 0 without connection, received %s", recv)
+	}
+}
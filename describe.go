@@ -0,0 +1,192 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+//#include <stdlib.h>
+//#include "ctlib.h"
+import "C"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"unsafe"
+)
+
+// ColumnDescription describes a column of a result set.
+type ColumnDescription struct {
+	Name string
+	Type ASEType
+	// Usertype is the usertype of the column in systypes.
+	Usertype  int
+	Nullable  bool
+	Precision int
+	Scale     int
+	// MaxLength is the maximum length of values in bytes.
+	MaxLength int
+}
+
+// newColumnDescription returns the ColumnDescription of a CS_DATAFMT
+// filled by ct_describe.
+func newColumnDescription(datafmt *C.CS_DATAFMT) ColumnDescription {
+	return ColumnDescription{
+		Name:      C.GoString(&datafmt.name[0]),
+		Type:      ASEType(datafmt.datatype).withUsertype(int(datafmt.usertype)),
+		Usertype:  int(datafmt.usertype),
+		Nullable:  datafmt.status&C.CS_CANBENULL == C.CS_CANBENULL,
+		Precision: int(datafmt.precision),
+		Scale:     int(datafmt.scale),
+		MaxLength: int(datafmt.maxlength),
+	}
+}
+
+// describeColumns describes the columns of the current result of cmd.
+func describeColumns(cmd *Command) ([]ColumnDescription, error) {
+	var numCols C.CS_INT
+	retval := C.ct_res_info(cmd.cmd, C.CS_NUMDATA, unsafe.Pointer(&numCols), C.CS_UNUSED, nil)
+	if retval != C.CS_SUCCEED {
+		return nil, makeError(retval, "Failed to read column count")
+	}
+
+	datafmt := (*C.CS_DATAFMT)(C.calloc(1, C.sizeof_CS_DATAFMT))
+	defer C.free(unsafe.Pointer(datafmt))
+
+	columns := make([]ColumnDescription, int(numCols))
+	for i := range columns {
+		retval = C.ct_describe(cmd.cmd, (C.CS_INT)(i+1), datafmt)
+		if retval != C.CS_SUCCEED {
+			return nil, makeError(retval, "Failed to retrieve description of column %d", i+1)
+		}
+
+		columns[i] = newColumnDescription(datafmt)
+	}
+
+	return columns, nil
+}
+
+// ResultDescriber is implemented by the driver.Stmt returned by
+// Connection.Prepare.
+type ResultDescriber interface {
+	// ResultColumns returns the columns of the result set of the
+	// prepared statement or nil if it returns no result set.
+	ResultColumns() ([]ColumnDescription, error)
+}
+
+// ResultColumns implements the ResultDescriber interface.
+//
+// The columns are described by the server on the first call, which
+// must not happen while rows returned by the statement are open.
+func (stmt *statement) ResultColumns() ([]ColumnDescription, error) {
+	if !stmt.described {
+		if stmt.cmd == nil {
+			return nil, errors.New("Statement is not prepared")
+		}
+
+		if err := stmt.fillResultColumns(); err != nil {
+			return nil, fmt.Errorf("Failed to retrieve result columns: %w", err)
+		}
+		stmt.described = true
+	}
+
+	return stmt.resultColumns, nil
+}
+
+// fillResultColumns retrieves the description of the result columns
+// of the prepared statement.
+func (stmt *statement) fillResultColumns() error {
+	name := C.CString(stmt.name)
+	defer C.free(unsafe.Pointer(name))
+
+	retval := C.ct_dynamic(stmt.cmd.cmd, C.CS_DESCRIBE_OUTPUT, name,
+		C.CS_NULLTERM, nil, C.CS_UNUSED)
+	if retval != C.CS_SUCCEED {
+		return makeError(retval, "Error when preparing output description")
+	}
+
+	retval = C.ct_send(stmt.cmd.cmd)
+	if retval != C.CS_SUCCEED {
+		return makeError(retval, "Error sending command to server")
+	}
+
+	for {
+		_, _, resultType, err := stmt.cmd.Response()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return fmt.Errorf("Received error while receiving output description: %w", err)
+		}
+
+		if resultType != C.CS_DESCRIBE_RESULT {
+			continue
+		}
+
+		columns, err := describeColumns(stmt.cmd)
+		if err != nil {
+			return err
+		}
+		stmt.resultColumns = columns
+	}
+
+	return nil
+}
+
+// DescribeQuery returns the columns of the first result set of query
+// without executing it. The query is sent with CS_OPT_FORMATONLY, hence
+// the server only returns the formats of the result sets.
+//
+// If query returns no result set nil is returned.
+func (conn *Connection) DescribeQuery(ctx context.Context, query string) ([]ColumnDescription, error) {
+	if err := conn.setFormatOnly(true); err != nil {
+		return nil, err
+	}
+	defer conn.setFormatOnly(false)
+
+	cmd, err := conn.exec(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer cmd.Drop()
+
+	// The columns are described with ct_describe instead of binding
+	// them as rows, which would report the bound types.
+	for {
+		var resultType C.CS_INT
+		retval := C.ct_results(cmd.cmd, &resultType)
+		switch retval {
+		case C.CS_SUCCEED:
+		case C.CS_END_RESULTS:
+			return nil, nil
+		default:
+			cmd.Cancel()
+			return nil, conn.commandError(makeError(retval, "Failed to read results"))
+		}
+
+		switch resultType {
+		case C.CS_ROW_RESULT:
+			columns, err := describeColumns(cmd)
+			cmd.Cancel()
+			return columns, err
+		case C.CS_CMD_FAIL:
+			cmd.Cancel()
+			return nil, conn.commandError(errors.New("Failed to describe query"))
+		}
+	}
+}
+
+// setFormatOnly sets the option CS_OPT_FORMATONLY of the connection.
+func (conn *Connection) setFormatOnly(enabled bool) error {
+	value := (C.CS_BOOL)(C.CS_FALSE)
+	if enabled {
+		value = C.CS_TRUE
+	}
+
+	retval := C.ct_options(conn.conn, C.CS_SET, C.CS_OPT_FORMATONLY, unsafe.Pointer(&value), C.CS_UNUSED, nil)
+	if retval != C.CS_SUCCEED {
+		return makeError(retval, "C.ct_options failed for CS_OPT_FORMATONLY")
+	}
+
+	return nil
+}
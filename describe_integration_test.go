@@ -0,0 +1,37 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// +build integration
+
+package ase
+
+import (
+	"context"
+	"testing"
+)
+
+func TestDescribeQuery(t *testing.T) {
+	withConnection(t, nil, func(conn *Connection) {
+		columns, err := conn.DescribeQuery(context.Background(), "select convert(varchar(10), 'a') as v, convert(varbinary(4), 0x01) as b")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		expected := []ColumnDescription{
+			{Name: "v", Type: VARCHAR, MaxLength: 10},
+			{Name: "b", Type: VARBINARY, MaxLength: 4},
+		}
+		if len(columns) != len(expected) {
+			t.Fatalf("Expected %d columns, received %d", len(expected), len(columns))
+		}
+
+		for i, column := range columns {
+			if column.Name != expected[i].Name || column.Type != expected[i].Type || column.MaxLength != expected[i].MaxLength {
+				t.Errorf("Column %d: expected %s %s(%d), received %s %s(%d)",
+					i+1, expected[i].Name, expected[i].Type, expected[i].MaxLength,
+					column.Name, column.Type, column.MaxLength)
+			}
+		}
+	})
+}
@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"testing"
+)
+
+func TestResultDescriber(t *testing.T) {
+	var stmt driver.Stmt = &statement{}
+	if _, ok := stmt.(ResultDescriber); !ok {
+		t.Errorf("Expected statement to implement ResultDescriber")
+	}
+}
+
+func TestResultColumnsDescribed(t *testing.T) {
+	columns := []ColumnDescription{
+		{Name: "id", Type: INT},
+		{Name: "name", Type: VARCHAR, Nullable: true, MaxLength: 30},
+	}
+
+	cases := map[string]struct {
+		stmt     *statement
+		expected []ColumnDescription
+	}{
+		"result set":    {&statement{resultColumns: columns, described: true}, columns},
+		"no result set": {&statement{described: true}, nil},
+	}
+
+	for title, cas := range cases {
+		t.Run(title, func(t *testing.T) {
+			// The statement has no command, the description must
+			// not be requested again.
+			recv, err := cas.stmt.ResultColumns()
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(recv, cas.expected) {
+				t.Errorf("Expected %v, received %v", cas.expected, recv)
+			}
+		})
+	}
+}
+
+func TestResultColumnsNotPrepared(t *testing.T) {
+	stmt := &statement{}
+	if _, err := stmt.ResultColumns(); err == nil {
+		t.Errorf("Expected error describing statement without command")
+	}
+
+	if stmt.described {
+		t.Errorf("Expected statement not to be marked as described")
+	}
+}
@@ -7,6 +7,7 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"log"
@@ -42,7 +43,26 @@ func DoMain() error {
 		}
 	}()
 
-	rows, err := db.Query("sp_adduser nologin")
+	conn, err := db.Conn(context.Background())
+	if err != nil {
+		return fmt.Errorf("error getting connection: %w", err)
+	}
+	defer conn.Close()
+
+	// The return status is not returned as a result set and is instead
+	// read from the result of the driver connection.
+	var returnStatus int32
+	err = conn.Raw(func(driverConn interface{}) error {
+		_, result, err := driverConn.(*ase.Connection).GenericExec(context.Background(), "sp_adduser nologin", nil)
+		if err != nil {
+			return err
+		}
+
+		if res, ok := result.(*ase.Result); ok && res != nil {
+			returnStatus, _ = res.ReturnStatus()
+		}
+		return nil
+	})
 	if err != nil {
 		fmt.Println("Messages from ASE server:")
 		for _, msg := range recorder.Messages {
@@ -51,22 +71,12 @@ func DoMain() error {
 		return err
 	}
 
-	var returnStatus int
-	for rows.Next() {
-		if err := rows.Scan(&returnStatus); err != nil {
-			return fmt.Errorf("error scanning return status: %w", err)
-		}
-
-		if returnStatus != 0 {
-			fmt.Println("Messages from ASE server:")
-			for _, msg := range recorder.Messages {
-				fmt.Printf("    %d: %s\n", msg.MessageNumber(), msg.Content())
-			}
-			return fmt.Errorf("sp_adduser failed with return status %d", returnStatus)
+	if returnStatus != 0 {
+		fmt.Println("Messages from ASE server:")
+		for _, msg := range recorder.Messages {
+			fmt.Printf("    %d: %s\n", msg.MessageNumber(), msg.Content())
 		}
-	}
-	if err := rows.Err(); err != nil {
-		return fmt.Errorf("rows reported error: %w", err)
+		return fmt.Errorf("sp_adduser failed with return status %d", returnStatus)
 	}
 
 	return nil
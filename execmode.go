@@ -0,0 +1,248 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+//#include <stdlib.h>
+//#include "ctlib.h"
+import "C"
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+	"time"
+	"unsafe"
+
+	"github.com/SAP/go-dblib/asetypes"
+)
+
+// ExecMode selects how GenericExec sends queries with arguments.
+type ExecMode string
+
+const (
+	// ExecModeDynamic prepares queries as dynamic SQL and executes
+	// the prepared statement with the arguments.
+	ExecModeDynamic ExecMode = "dynamic"
+	// ExecModeLanguage sends queries as language commands with the
+	// arguments as parameters. Placeholders are replaced by the
+	// variables @p1, @p2, ... or by the named variables.
+	ExecModeLanguage ExecMode = "language"
+)
+
+// parseExecMode returns the ExecMode for the passed string, which
+// defaults to ExecModeDynamic.
+func parseExecMode(s string) (ExecMode, error) {
+	switch mode := ExecMode(s); mode {
+	case "":
+		return ExecModeDynamic, nil
+	case ExecModeDynamic, ExecModeLanguage, ExecModeInterpolate:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("invalid exec mode: %q", s)
+	}
+}
+
+type execModeKey struct{}
+
+// WithExecMode returns a context overriding the exec-mode of the
+// connection for queries executed with it.
+func WithExecMode(ctx context.Context, mode ExecMode) context.Context {
+	return context.WithValue(ctx, execModeKey{}, mode)
+}
+
+// execModeFor returns the ExecMode for queries executed with ctx.
+func (conn *Connection) execModeFor(ctx context.Context) (ExecMode, error) {
+	mode, ok := ctx.Value(execModeKey{}).(ExecMode)
+	if !ok {
+		return conn.execMode, nil
+	}
+
+	return parseExecMode(string(mode))
+}
+
+// languageExec sends query as a language command with args as its
+// parameters.
+func (conn *Connection) languageExec(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, driver.Result, error) {
+	parsed, err := parseQuery(query, hasNamedArgs(args))
+	if err != nil {
+		return nil, nil, fmt.Errorf("Error parsing query: %w", err)
+	}
+
+	args, err = mapNamedArgs(parsed.paramNames, args)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(args) != parsed.numParams {
+		return nil, nil, fmt.Errorf("Mismatched argument count - expected %d, got %d",
+			parsed.numParams, len(args))
+	}
+
+	text, names := languageQuery(parsed)
+
+	cmd := &Command{conn: conn}
+	retval := C.ct_cmd_alloc(conn.conn, &cmd.cmd)
+	if retval != C.CS_SUCCEED {
+		return nil, nil, makeError(retval, "Failed to allocate command structure")
+	}
+
+	conn.resetMessages()
+
+	sql := C.CString(text)
+	defer C.free(unsafe.Pointer(sql))
+
+	retval = C.ct_command(cmd.cmd, C.CS_LANG_CMD, sql, C.CS_NULLTERM, C.CS_UNUSED)
+	if retval != C.CS_SUCCEED {
+		cmd.Drop()
+		return nil, nil, makeError(retval, "Failed to set language command")
+	}
+
+	// Named placeholders occurring multiple times are passed once.
+	passed := map[string]bool{}
+	for i, arg := range args {
+		if passed[names[i]] {
+			continue
+		}
+		passed[names[i]] = true
+
+		t, conv, value, err := conn.languageParam(arg.Value)
+		if err != nil {
+			cmd.Drop()
+			return nil, nil, fmt.Errorf("Error converting parameter %d: %w", i+1, err)
+		}
+
+		if err := cmd.param(i+1, names[i], t, conv, value); err != nil {
+			cmd.Drop()
+			return nil, nil, err
+		}
+	}
+
+	retval = C.ct_send(cmd.cmd)
+	if retval != C.CS_SUCCEED {
+		cmd.Drop()
+		return nil, nil, makeError(retval, "Failed to send command")
+	}
+
+	rows, result, err := cmd.ConsumeResponse(ctx)
+	if err != nil {
+		return nil, result, err
+	}
+
+	if rows != nil {
+		return rows, result, nil
+	}
+
+	cmd.Drop()
+	return nil, result, nil
+}
+
+// languageQuery replaces the placeholders of parsed with variables and
+// returns the query and the name of the variable of each placeholder.
+func languageQuery(parsed *parsedQuery) (string, []string) {
+	names := make([]string, parsed.numParams)
+
+	var b strings.Builder
+	last := 0
+	for i, offset := range parsed.placeholders {
+		if parsed.paramNames != nil {
+			names[i] = "@" + parsed.paramNames[i]
+		} else {
+			names[i] = fmt.Sprintf("@p%d", i+1)
+		}
+
+		b.WriteString(parsed.query[last:offset])
+		b.WriteString(names[i])
+		last = offset + 1
+	}
+	b.WriteString(parsed.query[last:])
+
+	return b.String(), names
+}
+
+// languageParam returns the ASEType a parameter of a language command
+// is passed as, the converter registered for the value and the value
+// converted for the type. Date and time values are converted like the
+// parameters of prepared statements.
+func (conn *Connection) languageParam(value driver.Value) (ASEType, TypeConverter, driver.Value, error) {
+	switch typed := value.(type) {
+	case *big.Rat:
+		dec, err := ratDecimal(typed)
+		return DECIMAL, nil, dec, err
+	case *Locator:
+		return typed.Type, nil, typed, nil
+	}
+
+	// Numbers are passed with the largest type of their kind.
+	sv := reflect.ValueOf(value)
+	switch sv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return BIGINT, nil, sv.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return UBIGINT, nil, sv.Uint(), nil
+	case reflect.Float32, reflect.Float64:
+		return FLOAT, nil, sv.Float(), nil
+	}
+
+	// Values of types with a registered converter are passed to the
+	// converter as-is, see Connection.CheckNamedValue.
+	if t, conv := registeredTypeFor(value); conv != nil {
+		return t, conv, value, nil
+	}
+	if conn.hasConverterFor(value) {
+		return 0, nil, nil, fmt.Errorf("values of %T are converted for a user-defined datatype, its type cannot be inferred", value)
+	}
+
+	value, err := asetypes.DefaultValueConverter.ConvertValue(value)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+
+	switch typed := value.(type) {
+	case nil:
+		return CHAR, nil, nil, nil
+	case bool:
+		return BIT, nil, typed, nil
+	case string:
+		return VARCHAR, nil, typed, nil
+	case []byte:
+		return VARBINARY, nil, typed, nil
+	case time.Time:
+		value, err := paramValue(BIGDATETIME, 0, 0, conn.native.paramLocation(), typed)
+		return BIGDATETIME, nil, value, err
+	case *asetypes.Decimal:
+		return DECIMAL, nil, typed, nil
+	default:
+		return 0, nil, nil, fmt.Errorf("cannot infer the type of %T", value)
+	}
+}
+
+// maxDecimalDigits is the maximum precision of decimals in ASE.
+const maxDecimalDigits = 38
+
+// ratDecimal returns rat as *asetypes.Decimal with the smallest scale
+// representing it exactly.
+func ratDecimal(rat *big.Rat) (*asetypes.Decimal, error) {
+	for scale := 0; scale <= maxDecimalDigits; scale++ {
+		s := rat.FloatString(scale)
+		if exact, _ := new(big.Rat).SetString(s); exact.Cmp(rat) != 0 {
+			continue
+		}
+
+		digits := len(strings.TrimLeft(strings.NewReplacer("-", "", ".", "").Replace(s), "0"))
+		precision := digits
+		if precision <= scale {
+			precision = scale + 1
+		}
+		if precision > maxDecimalDigits {
+			break
+		}
+
+		return asetypes.NewDecimalString(precision, scale, s)
+	}
+
+	return nil, fmt.Errorf("value %s cannot be represented as decimal", rat.RatString())
+}
@@ -0,0 +1,192 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"context"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/SAP/go-dblib/asetypes"
+)
+
+func TestExecModeFor(t *testing.T) {
+	conn := &Connection{execMode: ExecModeDynamic}
+
+	if mode, err := conn.execModeFor(context.Background()); err != nil || mode != ExecModeDynamic {
+		t.Errorf("Expected connection mode, received %q (%v)", mode, err)
+	}
+
+	ctx := WithExecMode(context.Background(), ExecModeLanguage)
+	if mode, err := conn.execModeFor(ctx); err != nil || mode != ExecModeLanguage {
+		t.Errorf("Expected context mode, received %q (%v)", mode, err)
+	}
+
+	ctx = WithExecMode(context.Background(), "invalid")
+	if _, err := conn.execModeFor(ctx); err == nil {
+		t.Errorf("Expected error for invalid mode")
+	}
+}
+
+func TestLanguageQuery(t *testing.T) {
+	cases := map[string]struct {
+		query    string
+		expected string
+		names    []string
+	}{
+		"positional": {
+			"select * from t where a = ? and b = '?' and c = ?",
+			"select * from t where a = @p1 and b = '?' and c = @p2",
+			[]string{"@p1", "@p2"},
+		},
+		"named": {
+			"select * from t where a = @a or b = @a and c = @c",
+			"select * from t where a = @a or b = @a and c = @c",
+			[]string{"@a", "@a", "@c"},
+		},
+		"none": {
+			"select 1", "select 1", []string{},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			parsed, err := parseQuery(tc.query, true)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			query, names := languageQuery(parsed)
+			if query != tc.expected {
+				t.Errorf("Expected query %q, received %q", tc.expected, query)
+			}
+
+			if !reflect.DeepEqual(names, tc.names) {
+				t.Errorf("Expected names %v, received %v", tc.names, names)
+			}
+		})
+	}
+}
+
+func TestLanguageParam(t *testing.T) {
+	ts := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	cases := map[string]struct {
+		value    interface{}
+		expected ASEType
+	}{
+		"nil":     {nil, CHAR},
+		"int":     {int(1), BIGINT},
+		"int8":    {int8(1), BIGINT},
+		"uint32":  {uint32(1), UBIGINT},
+		"float32": {float32(1), FLOAT},
+		"bool":    {true, BIT},
+		"string":  {"a", VARCHAR},
+		"bytes":   {[]byte("a"), VARBINARY},
+		"time":    {ts, BIGDATETIME},
+		"rat":     {big.NewRat(1, 8), DECIMAL},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			typ, _, _, err := (&Connection{}).languageParam(tc.value)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if typ != tc.expected {
+				t.Errorf("Expected %s, received %s", tc.expected, typ)
+			}
+		})
+	}
+
+	if _, _, _, err := (&Connection{}).languageParam(struct{}{}); err == nil {
+		t.Errorf("Expected error for struct")
+	}
+}
+
+func TestLanguageParamLocation(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("Location not available: %v", err)
+	}
+
+	conn := &Connection{native: &nativeConverter{location: berlin}}
+
+	_, _, value, err := conn.languageParam(time.Date(2020, time.March, 4, 5, 6, 7, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// The wall clock in the location is passed.
+	expected := time.Date(2020, time.March, 4, 6, 6, 7, 0, time.UTC)
+	if ts, ok := value.(time.Time); !ok || !ts.Equal(expected) {
+		t.Errorf("Expected %v, received %v", expected, value)
+	}
+}
+
+func TestLanguageParamConverter(t *testing.T) {
+	RegisterType(USER, customConverter{})
+	defer RegisterType(USER, nil)
+
+	value := customValue{"abc"}
+
+	typ, conv, converted, err := (&Connection{}).languageParam(value)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if typ != USER || conv == nil || converted != value {
+		t.Errorf("Expected %v passed to the converter of %s, received %v for %s", value, USER, converted, typ)
+	}
+}
+
+func TestLanguageParamUserTypeConverter(t *testing.T) {
+	conn := &Connection{userTypes: map[int]TypeConverter{100: customConverter{}}}
+
+	if _, _, _, err := conn.languageParam(customValue{"abc"}); err == nil {
+		t.Errorf("Expected error for value of a user-defined datatype")
+	}
+}
+
+func TestRatDecimal(t *testing.T) {
+	cases := map[string]struct {
+		rat              *big.Rat
+		precision, scale int
+	}{
+		"integer":  {big.NewRat(123, 1), 3, 0},
+		"fraction": {big.NewRat(1, 8), 4, 3},
+		"negative": {big.NewRat(-25, 10), 2, 1},
+		"zero":     {new(big.Rat), 1, 0},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			dec, err := ratDecimal(tc.rat)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if dec.Precision != tc.precision || dec.Scale != tc.scale {
+				t.Errorf("Expected precision %d and scale %d, received %d and %d",
+					tc.precision, tc.scale, dec.Precision, dec.Scale)
+			}
+
+			expected, _ := asetypes.NewDecimalString(tc.precision, tc.scale, tc.rat.FloatString(tc.scale))
+			if !dec.Cmp(*expected) {
+				t.Errorf("Expected %s, received %s", expected, dec)
+			}
+		})
+	}
+
+	if _, err := ratDecimal(big.NewRat(1, 3)); err == nil {
+		t.Errorf("Expected error for 1/3")
+	}
+}
@@ -0,0 +1,121 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/SAP/go-dblib/asetypes"
+)
+
+// identityQuery reads the identity value of the last insert of the
+// session. @@identity is a numeric(38, 0), which is converted to int64
+// by identityValue.
+const identityQuery = "select @@identity"
+
+// identityStatements returns whether query inserts rows and whether it
+// executes procedures, see parsedQuery. Queries which cannot be parsed
+// are assumed to do both.
+func identityStatements(query string) (bool, bool) {
+	parsed, err := parseQuery(query, false)
+	if err != nil {
+		return true, true
+	}
+
+	return parsed.inserts, parsed.executes
+}
+
+// insertedRows returns true if a statement of result affected rows.
+func insertedRows(result *Result) bool {
+	for _, stmt := range result.statements {
+		if !stmt.Failed && stmt.RowsAffected > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// withLastInsertID reads the identity value of the last insert and
+// sets it on result if last-insert-id is enabled.
+//
+// The identity value is only read if the command executed procedures,
+// which may insert rows without reporting it, or executed insert
+// statements which affected rows. Otherwise the last insert id is 0.
+func (conn *Connection) withLastInsertID(ctx context.Context, inserts, executes bool, result driver.Result) (driver.Result, error) {
+	res, ok := asResult(result)
+	if !ok || !conn.driverCtx.info.LastInsertID {
+		return result, nil
+	}
+
+	if !executes && !(inserts && insertedRows(res)) {
+		res.lastInsertID = 0
+		res.hasLastInsertID = true
+		return res, nil
+	}
+
+	cmd, err := conn.NewCommand(ctx, identityQuery)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading last insert id: %w", err)
+	}
+
+	rows, _, err := cmd.ConsumeResponse(ctx)
+	if err != nil {
+		cmd.Drop()
+		return nil, fmt.Errorf("Error reading last insert id: %w", err)
+	}
+
+	if rows == nil {
+		cmd.Drop()
+		return nil, errors.New("Error reading last insert id: no result set returned")
+	}
+	defer rows.Close()
+
+	values := make([]driver.Value, 1)
+	if err := rows.Next(values); err != nil {
+		return nil, fmt.Errorf("Error reading last insert id: %w", err)
+	}
+
+	id, err := identityValue(values[0])
+	if err != nil {
+		return nil, fmt.Errorf("Error reading last insert id: %w", err)
+	}
+
+	res.lastInsertID = id
+	res.hasLastInsertID = true
+	return res, nil
+}
+
+// identityValue converts the value of @@identity as returned with the
+// configured native types to int64.
+func identityValue(value driver.Value) (int64, error) {
+	rat := new(big.Rat)
+
+	switch typed := value.(type) {
+	case int64:
+		return typed, nil
+	case *asetypes.Decimal:
+		if _, ok := rat.SetString(typed.String()); !ok {
+			return 0, fmt.Errorf("invalid identity value %s", typed)
+		}
+	case *big.Rat:
+		rat.Set(typed)
+	case string:
+		if _, ok := rat.SetString(typed); !ok {
+			return 0, fmt.Errorf("invalid identity value %q", typed)
+		}
+	default:
+		return 0, fmt.Errorf("received value of type %T", value)
+	}
+
+	if !rat.IsInt() || !rat.Num().IsInt64() {
+		return 0, fmt.Errorf("identity value %s exceeds the range of int64", rat.RatString())
+	}
+
+	return rat.Num().Int64(), nil
+}
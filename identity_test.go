@@ -0,0 +1,109 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"database/sql/driver"
+	"math/big"
+	"testing"
+)
+
+func TestIdentityValue(t *testing.T) {
+	cases := map[string]struct {
+		value    driver.Value
+		expected int64
+	}{
+		"int64":          {int64(42), 42},
+		"decimal":        {mustDecimal(38, 0, "9223372036854775807"), 9223372036854775807},
+		"decimal zero":   {mustDecimal(38, 0, "0"), 0},
+		"rat":            {big.NewRat(123, 1), 123},
+		"string":         {"456", 456},
+		"negative":       {"-7", -7},
+		"string decimal": {"789.0", 789},
+	}
+
+	for title, cas := range cases {
+		t.Run(title, func(t *testing.T) {
+			id, err := identityValue(cas.value)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if id != cas.expected {
+				t.Errorf("Expected %d, received %d", cas.expected, id)
+			}
+		})
+	}
+}
+
+func TestIdentityValueInvalid(t *testing.T) {
+	cases := map[string]driver.Value{
+		"decimal overflow": mustDecimal(38, 0, "9223372036854775808"),
+		"rat overflow":     new(big.Rat).SetFrac(new(big.Int).Lsh(big.NewInt(1), 100), big.NewInt(1)),
+		"string overflow":  "99999999999999999999999999999999999999",
+		"fraction":         big.NewRat(1, 2),
+		"invalid string":   "abc",
+		"int32":            int32(1),
+		"nil":              nil,
+	}
+
+	for title, value := range cases {
+		t.Run(title, func(t *testing.T) {
+			if _, err := identityValue(value); err == nil {
+				t.Errorf("Expected error for %#v", value)
+			}
+		})
+	}
+}
+
+func TestIdentityStatements(t *testing.T) {
+	cases := map[string]struct {
+		query             string
+		inserts, executes bool
+	}{
+		"select":            {"select * from t", false, false},
+		"update":            {"update t set a = 1", false, false},
+		"insert":            {"insert into t values (1)", true, false},
+		"insert uppercase":  {"INSERT t SELECT * FROM s", true, false},
+		"merge":             {"merge into t using s on t.a = s.a when not matched then insert values (s.a)", true, false},
+		"batch":             {"update t set a = 1 insert t values (2)", true, false},
+		"exec":              {"exec proc @a = 1", false, true},
+		"execute":           {"execute proc", false, true},
+		"insert in string":  {"select 'insert' from t", false, false},
+		"insert in comment": {"select a from t -- insert\n", false, false},
+		"create procedure":  {"create procedure p as insert t values (1) exec q", false, false},
+		"unterminated":      {"select 'a from t", true, true},
+	}
+
+	for title, cas := range cases {
+		t.Run(title, func(t *testing.T) {
+			inserts, executes := identityStatements(cas.query)
+			if inserts != cas.inserts || executes != cas.executes {
+				t.Errorf("Expected inserts %t and executes %t, received %t and %t",
+					cas.inserts, cas.executes, inserts, executes)
+			}
+		})
+	}
+}
+
+func TestInsertedRows(t *testing.T) {
+	cases := map[string]struct {
+		statements []StatementResult
+		expected   bool
+	}{
+		"none":          {nil, false},
+		"no rows":       {[]StatementResult{{RowsAffected: 0}, {RowsAffected: -1}}, false},
+		"inserted rows": {[]StatementResult{{RowsAffected: -1}, {RowsAffected: 2}}, true},
+		"failed":        {[]StatementResult{{RowsAffected: -1, Failed: true}}, false},
+	}
+
+	for title, cas := range cases {
+		t.Run(title, func(t *testing.T) {
+			if recv := insertedRows(&Result{statements: cas.statements}); recv != cas.expected {
+				t.Errorf("Expected %t, received %t", cas.expected, recv)
+			}
+		})
+	}
+}
@@ -22,6 +22,24 @@ type Info struct {
 
 	LogClientMsgs bool `json:"log-client-msgs" doc:"Log client messages"`
 	LogServerMsgs bool `json:"log-server-msgs" doc:"Log server messages"`
+
+	LOBStreaming bool `json:"lob-streaming" doc:"Return TEXT, UNITEXT and IMAGE columns as io.Reader"`
+
+	TrimChar bool `json:"trim-char" doc:"Remove trailing blanks from character columns"`
+
+	NativeTypes   bool   `json:"native-types" doc:"Return idiomatic Go types for numeric, decimal and date columns"`
+	NativeDecimal string `json:"native-decimal" doc:"Go type of decimal and money columns with native-types, 'rat' (default) or 'string'"`
+	Location      string `json:"location" doc:"Location of date and time values with native-types, defaults to UTC"`
+
+	StatementCacheSize int `json:"stmt-cache-size" doc:"Number of prepared statements cached per connection, 0 disables the cache"`
+
+	FetchBatchSize int `json:"fetch-batch-size" doc:"Number of rows fetched at once, defaults to 1"`
+
+	LastInsertID bool `json:"last-insert-id" doc:"Read @@identity after each exec to return it from LastInsertId"`
+
+	ReturnStatusRows bool `json:"return-status-rows" doc:"Return the return status of stored procedures as result set"`
+
+	ExecMode string `json:"exec-mode" doc:"How queries with arguments are sent, 'dynamic' (default), 'language' or 'interpolate'"`
 }
 
 // NewInfo returns a bare Info for github.com/SAP/go-dblib/dsn with defaults.
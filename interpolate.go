@@ -0,0 +1,202 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/SAP/go-dblib/asetypes"
+)
+
+// ExecModeInterpolate renders the arguments into the query as literals
+// and sends the query as a single language command.
+const ExecModeInterpolate ExecMode = "interpolate"
+
+// interpolateExec renders args into query and sends it as a language
+// command.
+func (conn *Connection) interpolateExec(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, driver.Result, error) {
+	parsed, err := parseQuery(query, hasNamedArgs(args))
+	if err != nil {
+		return nil, nil, fmt.Errorf("Error parsing query: %w", err)
+	}
+
+	args, err = mapNamedArgs(parsed.paramNames, args)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	text, err := interpolateQuery(parsed, args, conn.native.paramLocation())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return conn.GenericExec(ctx, text, nil)
+}
+
+// interpolateQuery replaces the placeholders of parsed with the
+// literals of args. Date and time values are converted into loc, UTC
+// if loc is nil.
+func interpolateQuery(parsed *parsedQuery, args []driver.NamedValue, loc *time.Location) (string, error) {
+	if len(args) != parsed.numParams {
+		return "", fmt.Errorf("Mismatched argument count - expected %d, got %d",
+			parsed.numParams, len(args))
+	}
+
+	var b strings.Builder
+	last := 0
+	for i, offset := range parsed.placeholders {
+		lit, err := literal(args[i].Value, loc)
+		if err != nil {
+			return "", fmt.Errorf("Error rendering argument %d: %w", i+1, err)
+		}
+
+		b.WriteString(parsed.query[last:offset])
+		b.WriteString(lit)
+		last = offset + 1
+	}
+	b.WriteString(parsed.query[last:])
+
+	return b.String(), nil
+}
+
+// literal returns the ASE literal representing value exactly. Values
+// which cannot be represented exactly are refused.
+func literal(value driver.Value, loc *time.Location) (string, error) {
+	switch typed := value.(type) {
+	case nil:
+		return "null", nil
+	case bool:
+		if typed {
+			return "1", nil
+		}
+		return "0", nil
+	case string:
+		return stringLiteral(typed)
+	case []byte:
+		// ASE has no literal for empty binary values.
+		if len(typed) == 0 {
+			return "", errors.New("empty binary values cannot be represented as literal")
+		}
+		return "0x" + hex.EncodeToString(typed), nil
+	case time.Time:
+		return timeLiteral(typed, loc)
+	case *asetypes.Decimal:
+		return decimalLiteral(typed), nil
+	case *big.Rat:
+		dec, err := ratDecimal(typed)
+		if err != nil {
+			return "", err
+		}
+		return decimalLiteral(dec), nil
+	case *Locator:
+		return "", errors.New("locators cannot be represented as literal")
+	}
+
+	sv := reflect.ValueOf(value)
+	switch sv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(sv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(sv.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return floatLiteral(sv.Float())
+	}
+
+	converted, err := asetypes.DefaultValueConverter.ConvertValue(value)
+	if err != nil {
+		return "", err
+	}
+
+	if reflect.TypeOf(converted) == reflect.TypeOf(value) {
+		return "", fmt.Errorf("cannot render value of type %T as literal", value)
+	}
+	return literal(converted, loc)
+}
+
+// stringLiteral returns s as quoted string literal.
+//
+// ASE removes a backslash followed by a newline in string literals,
+// these are rendered as concatenation with char(10). Empty segments are
+// left out as ASE would read them as a blank.
+func stringLiteral(s string) (string, error) {
+	// ASE interprets the empty string literal as a single blank.
+	if s == "" {
+		return "", errors.New("empty strings cannot be represented as literal")
+	}
+
+	if strings.IndexByte(s, 0) >= 0 {
+		return "", errors.New("strings containing null bytes cannot be represented as literal")
+	}
+
+	if !utf8.ValidString(s) {
+		return "", errors.New("strings with invalid UTF-8 cannot be represented as literal")
+	}
+
+	segments := strings.Split(s, "\\\n")
+	parts := make([]string, 0, 2*len(segments))
+	for i, segment := range segments {
+		last := i == len(segments)-1
+		if !last {
+			segment += "\\"
+		}
+		if segment != "" {
+			parts = append(parts, "'"+strings.ReplaceAll(segment, "'", "''")+"'")
+		}
+		if !last {
+			parts = append(parts, "char(10)")
+		}
+	}
+
+	return strings.Join(parts, " + "), nil
+}
+
+// floatLiteral returns f as approximate numeric literal, which ASE
+// parses as float.
+func floatLiteral(f float64) (string, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return "", fmt.Errorf("%v cannot be represented as literal", f)
+	}
+
+	// The shortest representation with an exponent is parsed to the
+	// same float64.
+	return strconv.FormatFloat(f, 'e', -1, 64), nil
+}
+
+// decimalLiteral returns dec as exact numeric literal with its
+// precision and scale.
+func decimalLiteral(dec *asetypes.Decimal) string {
+	return fmt.Sprintf("convert(numeric(%d,%d), %s)", dec.Precision, dec.Scale, dec.String())
+}
+
+// timeLiteral returns t as bigdatetime literal in loc. The literal uses
+// the unseparated ISO format, which is independent of the language and
+// dateformat of the session.
+func timeLiteral(t time.Time, loc *time.Location) (string, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	t = t.In(loc)
+
+	if t.Year() < 1 || t.Year() > 9999 {
+		return "", fmt.Errorf("year %d cannot be represented as bigdatetime", t.Year())
+	}
+
+	if t.Nanosecond()%int(time.Microsecond) != 0 {
+		return "", fmt.Errorf("%s has a higher precision than microseconds", t)
+	}
+
+	return fmt.Sprintf("convert(bigdatetime, '%s')", t.Format("20060102 15:04:05.000000")), nil
+}
@@ -0,0 +1,189 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"database/sql/driver"
+	"math"
+	"math/big"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/SAP/go-dblib/asetypes"
+)
+
+func TestLiteral(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("Location unavailable: %v", err)
+	}
+
+	dec, err := asetypes.NewDecimalString(10, 2, "-12.30")
+	if err != nil {
+		t.Fatalf("Error creating decimal: %v", err)
+	}
+
+	cases := map[string]struct {
+		value    driver.Value
+		loc      *time.Location
+		expected string
+	}{
+		"nil":               {nil, nil, "null"},
+		"true":              {true, nil, "1"},
+		"false":             {false, nil, "0"},
+		"int":               {int(-5), nil, "-5"},
+		"int64 min":         {int64(math.MinInt64), nil, "-9223372036854775808"},
+		"uint64 max":        {uint64(math.MaxUint64), nil, "18446744073709551615"},
+		"float":             {1.5, nil, "1.5e+00"},
+		"float small":       {0.1, nil, "1e-01"},
+		"float32":           {float32(0.1), nil, "1.0000000149011612e-01"},
+		"string":            {"abc", nil, "'abc'"},
+		"single quote":      {"it's", nil, "'it''s'"},
+		"only quotes":       {"''", nil, "''''''"},
+		"double quote":      {`say "hi"`, nil, `'say "hi"'`},
+		"injection":         {"'; drop table t --", nil, "'''; drop table t --'"},
+		"comment":           {"/* x */ -- y", nil, "'/* x */ -- y'"},
+		"placeholder":       {"? @a", nil, "'? @a'"},
+		"backslash":         {`a\b`, nil, `'a\b'`},
+		"backslash end":     {`a\`, nil, `'a\'`},
+		"newline":           {"a\nb", nil, "'a\nb'"},
+		"line continuation": {"a\\\nb", nil, "'a\\' + char(10) + 'b'"},
+		"continuation end":  {"a\\\n", nil, "'a\\' + char(10)"},
+		"leading backslash": {"\\\nb", nil, "'\\' + char(10) + 'b'"},
+		"unicode":           {"äöü €", nil, "'äöü €'"},
+		"bytes":             {[]byte{0x00, 0xab, 0xff}, nil, "0x00abff"},
+		"timestamp":         {Timestamp{0, 0, 0, 0, 0, 0, 0x10, 0x01}, nil, "0x0000000000001001"},
+		"time":              {time.Date(2020, time.March, 4, 5, 6, 7, 123456000, time.UTC), nil, "convert(bigdatetime, '20200304 05:06:07.123456')"},
+		"time location":     {time.Date(2020, time.March, 4, 5, 6, 7, 0, time.UTC), berlin, "convert(bigdatetime, '20200304 06:06:07.000000')"},
+		"time to utc":       {time.Date(2020, time.March, 4, 5, 6, 7, 0, berlin), nil, "convert(bigdatetime, '20200304 04:06:07.000000')"},
+		"decimal":           {dec, nil, "convert(numeric(10,2), -12.3)"},
+		"rat":               {big.NewRat(-1, 8), nil, "convert(numeric(4,3), -0.125)"},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			value := tc.value
+			if valuer, ok := value.(driver.Valuer); ok {
+				var err error
+				if value, err = valuer.Value(); err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+			}
+
+			lit, err := literal(value, tc.loc)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if lit != tc.expected {
+				t.Errorf("Expected %s, received %s", tc.expected, lit)
+			}
+		})
+	}
+}
+
+func TestLiteralFloatExact(t *testing.T) {
+	for _, f := range []float64{0.1, 1.0 / 3, math.MaxFloat64, math.SmallestNonzeroFloat64, -2.5e-300, 123456789.123456789} {
+		lit, err := literal(f, nil)
+		if err != nil {
+			t.Fatalf("Unexpected error for %v: %v", f, err)
+		}
+
+		parsed, err := strconv.ParseFloat(lit, 64)
+		if err != nil {
+			t.Fatalf("Error parsing %s: %v", lit, err)
+		}
+
+		if parsed != f {
+			t.Errorf("Literal %s of %v parses to %v", lit, f, parsed)
+		}
+	}
+}
+
+func TestLiteralInvalid(t *testing.T) {
+	cases := map[string]driver.Value{
+		"empty string":    "",
+		"null byte":       "a\x00b",
+		"invalid utf8":    "a\xffb",
+		"empty bytes":     []byte{},
+		"nan":             math.NaN(),
+		"inf":             math.Inf(1),
+		"nanoseconds":     time.Date(2020, time.March, 4, 5, 6, 7, 1, time.UTC),
+		"year zero":       time.Date(0, time.January, 1, 0, 0, 0, 0, time.UTC),
+		"year 10000":      time.Date(10000, time.January, 1, 0, 0, 0, 0, time.UTC),
+		"inexact rat":     big.NewRat(1, 3),
+		"locator":         &Locator{},
+		"struct":          struct{}{},
+		"unsupported map": map[string]string{},
+	}
+
+	for name, value := range cases {
+		value := value
+		t.Run(name, func(t *testing.T) {
+			if lit, err := literal(value, nil); err == nil {
+				t.Errorf("Expected error rendering %v, received %s", value, lit)
+			}
+		})
+	}
+}
+
+func TestInterpolateQuery(t *testing.T) {
+	cases := map[string]struct {
+		query    string
+		args     []driver.NamedValue
+		expected string
+	}{
+		"positional": {
+			"select * from t where a = ? and b = '?' and c = ?",
+			[]driver.NamedValue{{Ordinal: 1, Value: "x'y"}, {Ordinal: 2, Value: int64(3)}},
+			"select * from t where a = 'x''y' and b = '?' and c = 3",
+		},
+		"named": {
+			"select * from t where a = @a or b = @a and c = @c -- @a",
+			[]driver.NamedValue{{Name: "c", Ordinal: 1, Value: nil}, {Name: "a", Ordinal: 2, Value: true}},
+			"select * from t where a = 1 or b = 1 and c = null -- @a",
+		},
+		"placeholder in value": {
+			"select ?, ?",
+			[]driver.NamedValue{{Ordinal: 1, Value: "?"}, {Ordinal: 2, Value: "@x"}},
+			"select '?', '@x'",
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			parsed, err := parseQuery(tc.query, hasNamedArgs(tc.args))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			args, err := mapNamedArgs(parsed.paramNames, tc.args)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			query, err := interpolateQuery(parsed, args, nil)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if query != tc.expected {
+				t.Errorf("Expected %q, received %q", tc.expected, query)
+			}
+		})
+	}
+
+	parsed, err := parseQuery("select ?, ?", false)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if _, err := interpolateQuery(parsed, []driver.NamedValue{{Ordinal: 1, Value: 1}}, nil); err == nil {
+		t.Errorf("Expected error for missing argument")
+	}
+}
@@ -0,0 +1,326 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+//#include <stdlib.h>
+//#include "ctlib.h"
+import "C"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"unsafe"
+)
+
+// Interface satisfaction checks.
+var _ io.Reader = (*LOBReader)(nil)
+
+// ErrLOBReaderInvalid is returned by LOBReader.Read if the row the
+// reader belongs to is no longer the current row of the result set.
+var ErrLOBReaderInvalid = errors.New("LOB reader is no longer valid")
+
+// lobChunkSize is the number of bytes sent with each call to
+// ct_send_data.
+const lobChunkSize = 32768
+
+// isLOBType returns true for ASETypes which can be streamed with
+// ct_get_data.
+func isLOBType(t ASEType) bool {
+	switch t {
+	case TEXT, UNITEXT, IMAGE:
+		return true
+	default:
+		return false
+	}
+}
+
+// streamedColumns returns which of the columns of the passed types are
+// not bound and streamed with ct_get_data instead.
+//
+// Client-Library does not fetch bound columns following an unbound
+// column, hence all streamed columns must be at the end of the select
+// list.
+func streamedColumns(types []ASEType, stream bool) ([]bool, error) {
+	streamed := make([]bool, len(types))
+	if !stream {
+		return streamed, nil
+	}
+
+	streaming := false
+	for i, t := range types {
+		if isLOBType(t) {
+			streamed[i] = true
+			streaming = true
+			continue
+		}
+
+		if streaming {
+			return nil, fmt.Errorf("Column %d of type %s follows a streamed column, streamed columns must be selected last", i+1, t)
+		}
+	}
+
+	return streamed, nil
+}
+
+// maxTextSize is the largest value of CS_OPT_TEXTSIZE. It is set when
+// LOBs are streamed, as the server truncates TEXT, UNITEXT and IMAGE
+// values to @@textsize, which defaults to 32 KB.
+const maxTextSize = math.MaxInt32
+
+// setTextSize sets the maximum size of TEXT, UNITEXT and IMAGE values
+// the server returns.
+func (conn *Connection) setTextSize(size int) error {
+	value := (C.CS_INT)(size)
+	retval := C.ct_options(conn.conn, C.CS_SET, C.CS_OPT_TEXTSIZE, unsafe.Pointer(&value), C.CS_UNUSED, nil)
+	if retval != C.CS_SUCCEED {
+		return makeError(retval, "C.ct_options failed for CS_OPT_TEXTSIZE")
+	}
+
+	return nil
+}
+
+// LOBReader is returned by Rows.Next for TEXT, UNITEXT and IMAGE columns
+// when the property LOBStreaming is set. The column data is read from
+// the server in chunks through ct_get_data as the reader is consumed.
+//
+// The data is returned as sent by the server - TEXT in the client
+// character set, UNITEXT as UTF-16 and IMAGE as-is. A NULL value
+// results in a reader without data.
+//
+// A LOBReader is only valid until the next call of Rows.Next or
+// Rows.Close. Readers of a row must be consumed in the order of their
+// columns - reading a column skips the remaining data of all preceding
+// streamed columns.
+type LOBReader struct {
+	rows *Rows
+	// item is the column number of the LOB in the result set.
+	item int
+	// row is the rowNumber of rows the reader was created for.
+	row  uint64
+	done bool
+}
+
+// Read implements the io.Reader interface.
+func (lob *LOBReader) Read(p []byte) (int, error) {
+	if lob.done {
+		return 0, io.EOF
+	}
+
+	if lob.rows.cmd == nil || lob.row != lob.rows.rowNumber {
+		return 0, ErrLOBReaderInvalid
+	}
+
+	if lob.item < lob.rows.lobItem {
+		return 0, ErrLOBReaderInvalid
+	}
+	lob.rows.lobItem = lob.item
+
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	if len(p) > math.MaxInt32 {
+		p = p[:math.MaxInt32]
+	}
+
+	var outlen C.CS_INT
+	retval := C.ct_get_data(lob.rows.cmd.cmd, (C.CS_INT)(lob.item), unsafe.Pointer(&p[0]), (C.CS_INT)(len(p)), &outlen)
+	switch retval {
+	case C.CS_SUCCEED:
+		return int(outlen), nil
+	case C.CS_END_ITEM, C.CS_END_DATA:
+		lob.done = true
+		return int(outlen), io.EOF
+	default:
+		return int(outlen), makeError(retval, "Failed to read data of column %d", lob.item)
+	}
+}
+
+// WriteLOB writes size bytes read from r into the TEXT, UNITEXT or
+// IMAGE column of the single row in table matching the where clause.
+//
+// The data is sent in chunks with ct_send_data, the value is never
+// held in memory in its entirety. table, column and where are inserted
+// into the SQL text as-is and must not contain untrusted input.
+//
+// The column must already hold a text pointer - a column that was
+// never written to must be initialized first, e.g. by an UPDATE
+// setting it to NULL.
+func (conn *Connection) WriteLOB(ctx context.Context, table, column, where string, r io.Reader, size int64) error {
+	if size < 0 || size > math.MaxInt32 {
+		return fmt.Errorf("Invalid LOB size %d", size)
+	}
+
+	iodesc, err := conn.lobIODesc(ctx, table, column, where)
+	if err != nil {
+		return err
+	}
+	defer C.free(unsafe.Pointer(iodesc))
+
+	cmd := &Command{conn: conn}
+	retval := C.ct_cmd_alloc(conn.conn, &cmd.cmd)
+	if retval != C.CS_SUCCEED {
+		return makeError(retval, "Failed to allocate command structure")
+	}
+	defer cmd.Drop()
+
+	retval = C.ct_command(cmd.cmd, C.CS_SEND_DATA_CMD, nil, C.CS_UNUSED, C.CS_COLUMN_DATA)
+	if retval != C.CS_SUCCEED {
+		return makeError(retval, "Failed to set send data command")
+	}
+
+	iodesc.total_txtlen = (C.CS_INT)(size)
+	iodesc.log_on_update = C.CS_TRUE
+
+	retval = C.ct_data_info(cmd.cmd, C.CS_SET, C.CS_UNUSED, iodesc)
+	if retval != C.CS_SUCCEED {
+		return makeError(retval, "Failed to set I/O descriptor")
+	}
+
+	buf := C.malloc(lobChunkSize)
+	defer C.free(buf)
+	chunk := (*[lobChunkSize]byte)(buf)[:]
+
+	// data is always a prefix of chunk, which is backed by buf.
+	err = sendLOB(ctx, r, size, chunk, func(data []byte) error {
+		retval := C.ct_send_data(cmd.cmd, buf, (C.CS_INT)(len(data)))
+		if retval != C.CS_SUCCEED {
+			return makeError(retval, "Failed to send data")
+		}
+		return nil
+	})
+	if err != nil {
+		cmd.Cancel()
+		return err
+	}
+
+	retval = C.ct_send(cmd.cmd)
+	if retval != C.CS_SUCCEED {
+		return makeError(retval, "Failed to send command")
+	}
+
+	return cmd.discardResults()
+}
+
+// sendLOB reads size bytes from r into chunk and passes each filled
+// part of chunk to send. An error is returned if r returns less than
+// size bytes, further bytes are not read.
+func sendLOB(ctx context.Context, r io.Reader, size int64, chunk []byte, send func([]byte) error) error {
+	r = io.LimitReader(r, size)
+	written := int64(0)
+	for written < size {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		n, err := io.ReadFull(r, chunk)
+		if n > 0 {
+			if err := send(chunk[:n]); err != nil {
+				return err
+			}
+			written += int64(n)
+		}
+
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("Error reading LOB data: %w", err)
+		}
+	}
+
+	if written != size {
+		return fmt.Errorf("Reader returned %d bytes, expected %d", written, size)
+	}
+
+	return nil
+}
+
+// lobIODesc selects column from the row in table matching where and
+// returns the I/O descriptor of the column.
+//
+// The returned descriptor is allocated in C memory and must be freed
+// by the caller.
+func (conn *Connection) lobIODesc(ctx context.Context, table, column, where string) (*C.CS_IODESC, error) {
+	cmd, err := conn.exec(ctx, fmt.Sprintf("select %s from %s where %s", column, table, where))
+	if err != nil {
+		return nil, err
+	}
+	defer cmd.Drop()
+
+	iodesc := (*C.CS_IODESC)(C.calloc(1, C.sizeof_CS_IODESC))
+	found := false
+
+	for {
+		var resultType C.CS_INT
+		retval := C.ct_results(cmd.cmd, &resultType)
+		if retval == C.CS_END_RESULTS {
+			break
+		}
+		if retval != C.CS_SUCCEED {
+			C.free(unsafe.Pointer(iodesc))
+			cmd.Cancel()
+			return nil, makeError(retval, "Failed to read results")
+		}
+
+		switch resultType {
+		case C.CS_ROW_RESULT:
+			for {
+				retval = C.ct_fetch(cmd.cmd, C.CS_UNUSED, C.CS_UNUSED, C.CS_UNUSED, nil)
+				if retval == C.CS_END_DATA {
+					break
+				}
+				if retval != C.CS_SUCCEED {
+					C.free(unsafe.Pointer(iodesc))
+					cmd.Cancel()
+					return nil, makeError(retval, "Failed to fetch row")
+				}
+
+				if found {
+					C.free(unsafe.Pointer(iodesc))
+					cmd.Cancel()
+					return nil, fmt.Errorf("Condition %q matches more than one row in %s", where, table)
+				}
+				found = true
+
+				// ct_data_info requires the column to be read by
+				// ct_get_data first - a read of zero bytes only
+				// retrieves the I/O descriptor.
+				var dummy C.CS_BYTE
+				retval = C.ct_get_data(cmd.cmd, 1, unsafe.Pointer(&dummy), 0, nil)
+				if retval != C.CS_SUCCEED && retval != C.CS_END_ITEM && retval != C.CS_END_DATA {
+					C.free(unsafe.Pointer(iodesc))
+					cmd.Cancel()
+					return nil, makeError(retval, "Failed to read column %s", column)
+				}
+
+				retval = C.ct_data_info(cmd.cmd, C.CS_GET, 1, iodesc)
+				if retval != C.CS_SUCCEED {
+					C.free(unsafe.Pointer(iodesc))
+					cmd.Cancel()
+					return nil, makeError(retval, "Failed to retrieve I/O descriptor")
+				}
+			}
+		case C.CS_CMD_FAIL:
+			C.free(unsafe.Pointer(iodesc))
+			cmd.Cancel()
+			return nil, fmt.Errorf("Failed to select column %s from %s", column, table)
+		}
+	}
+
+	if !found {
+		C.free(unsafe.Pointer(iodesc))
+		return nil, fmt.Errorf("Condition %q matches no row in %s", where, table)
+	}
+
+	if iodesc.textptrlen == 0 {
+		C.free(unsafe.Pointer(iodesc))
+		return nil, fmt.Errorf("Column %s has no text pointer, initialize it before writing", column)
+	}
+
+	return iodesc, nil
+}
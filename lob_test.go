@@ -0,0 +1,193 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestIsLOBType(t *testing.T) {
+	cases := map[ASEType]bool{
+		TEXT:       true,
+		UNITEXT:    true,
+		IMAGE:      true,
+		CHAR:       false,
+		VARCHAR:    false,
+		LONGCHAR:   false,
+		BINARY:     false,
+		LONGBINARY: false,
+		INT:        false,
+	}
+
+	for asetype, expected := range cases {
+		if recv := isLOBType(asetype); recv != expected {
+			t.Errorf("%s: expected %t, received %t", asetype, expected, recv)
+		}
+	}
+}
+
+func TestStreamedColumns(t *testing.T) {
+	cases := map[string]struct {
+		types    []ASEType
+		stream   bool
+		expected []bool
+		err      bool
+	}{
+		"no streaming": {
+			types:    []ASEType{TEXT, INT, IMAGE},
+			stream:   false,
+			expected: []bool{false, false, false},
+		},
+		"no lob columns": {
+			types:    []ASEType{INT, VARCHAR},
+			stream:   true,
+			expected: []bool{false, false},
+		},
+		"lob columns last": {
+			types:    []ASEType{INT, VARCHAR, TEXT, IMAGE},
+			stream:   true,
+			expected: []bool{false, false, true, true},
+		},
+		"only lob columns": {
+			types:    []ASEType{UNITEXT},
+			stream:   true,
+			expected: []bool{true},
+		},
+		"bound column after lob column": {
+			types:  []ASEType{TEXT, INT},
+			stream: true,
+			err:    true,
+		},
+		"bound column between lob columns": {
+			types:  []ASEType{INT, IMAGE, CHAR, TEXT},
+			stream: true,
+			err:    true,
+		},
+	}
+
+	for title, cas := range cases {
+		t.Run(title, func(t *testing.T) {
+			recv, err := streamedColumns(cas.types, cas.stream)
+			if cas.err {
+				if err == nil {
+					t.Errorf("Expected error, received %v", recv)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(recv, cas.expected) {
+				t.Errorf("Expected %v, received %v", cas.expected, recv)
+			}
+		})
+	}
+}
+
+// errReader returns data and then err.
+type errReader struct {
+	data []byte
+	err  error
+}
+
+func (r *errReader) Read(p []byte) (int, error) {
+	if len(r.data) == 0 {
+		return 0, r.err
+	}
+	n := copy(p, r.data)
+	r.data = r.data[n:]
+	return n, nil
+}
+
+func TestSendLOB(t *testing.T) {
+	cases := map[string]struct {
+		data   string
+		size   int64
+		chunks []string
+	}{
+		"empty":              {"", 0, nil},
+		"single chunk":       {"abc", 3, []string{"abc"}},
+		"exact chunks":       {"abcdefgh", 8, []string{"abcd", "efgh"}},
+		"partial last chunk": {"abcdef", 6, []string{"abcd", "ef"}},
+		"longer reader":      {"abcdefgh", 5, []string{"abcd", "e"}},
+	}
+
+	for title, cas := range cases {
+		t.Run(title, func(t *testing.T) {
+			var chunks []string
+			err := sendLOB(context.Background(), strings.NewReader(cas.data), cas.size, make([]byte, 4),
+				func(data []byte) error {
+					chunks = append(chunks, string(data))
+					return nil
+				})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(chunks, cas.chunks) {
+				t.Errorf("Expected chunks %q, received %q", cas.chunks, chunks)
+			}
+		})
+	}
+}
+
+func TestSendLOBErrors(t *testing.T) {
+	errRead := errors.New("read failed")
+	errSend := errors.New("send failed")
+
+	cases := map[string]struct {
+		r       io.Reader
+		size    int64
+		send    error
+		wrapped error
+	}{
+		"short reader": {strings.NewReader("abc"), 6, nil, nil},
+		"reader error": {&errReader{[]byte("abcdef"), errRead}, 10, nil, errRead},
+		"send error":   {strings.NewReader("abcdef"), 6, errSend, errSend},
+	}
+
+	for title, cas := range cases {
+		t.Run(title, func(t *testing.T) {
+			err := sendLOB(context.Background(), cas.r, cas.size, make([]byte, 4), func([]byte) error {
+				return cas.send
+			})
+			if err == nil {
+				t.Fatalf("Expected error")
+			}
+
+			if cas.wrapped != nil && !errors.Is(err, cas.wrapped) {
+				t.Errorf("Expected error wrapping %v, received %v", cas.wrapped, err)
+			}
+		})
+	}
+}
+
+func TestSendLOBCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sent := 0
+	err := sendLOB(ctx, strings.NewReader("abcdefgh"), 8, make([]byte, 4), func([]byte) error {
+		sent++
+		// Cancel while the first chunk is sent.
+		cancel()
+		return nil
+	})
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected %v, received %v", context.Canceled, err)
+	}
+
+	if sent != 1 {
+		t.Errorf("Expected 1 chunk to be sent before cancelling, received %d", sent)
+	}
+}
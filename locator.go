@@ -0,0 +1,200 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+//#include "ctlib.h"
+import "C"
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"unsafe"
+)
+
+// locatorBufferSize is the size of the buffer the locator value is
+// read into.
+const locatorBufferSize = 256
+
+// isLocatorType returns true for the ASETypes of LOB locators.
+func isLocatorType(t ASEType) bool {
+	switch t {
+	case TEXTLOCATOR, IMAGELOCATOR, UNITEXTLOCATOR:
+		return true
+	default:
+		return false
+	}
+}
+
+// Locator is a reference to a LOB on the server as returned for
+// columns and parameters of the types TEXTLOCATOR, IMAGELOCATOR and
+// UNITEXTLOCATOR.
+//
+// The LOB itself is not transferred when the locator is fetched.
+// Instead the length can be read and substrings can be requested from
+// the server as required. A Locator can be passed as an argument for
+// parameters of locator types.
+//
+// A locator is only valid on the server in the transaction it was
+// created in. Locators must be closed to release the memory allocated
+// by Client-Library, locators which are still open when the connection
+// is closed are released with it. Null values of locator columns are
+// returned as nil.
+type Locator struct {
+	conn *Connection
+	// rows are the rows the locator was fetched with, nil for
+	// locators not fetched from a result set.
+	rows *Rows
+	loc  *C.CS_LOCATOR
+	// Type is the ASEType of the locator.
+	Type ASEType
+}
+
+// newLocator allocates a locator which can be bound to a column.
+func newLocator(conn *Connection, t ASEType) (*Locator, error) {
+	loc := &Locator{conn: conn, Type: t}
+
+	retval := C.cs_locator_alloc(conn.driverCtx.ctx, &loc.loc)
+	if retval != C.CS_SUCCEED {
+		return nil, makeError(retval, "Failed to allocate locator")
+	}
+
+	if conn.locators == nil {
+		conn.locators = make(map[*Locator]struct{})
+	}
+	conn.locators[loc] = struct{}{}
+
+	return loc, nil
+}
+
+// Close releases the memory of the locator.
+func (loc *Locator) Close() error {
+	if loc.loc == nil {
+		return nil
+	}
+
+	retval := C.cs_locator_drop(loc.conn.driverCtx.ctx, loc.loc)
+	if retval != C.CS_SUCCEED {
+		return makeError(retval, "Failed to drop locator")
+	}
+
+	delete(loc.conn.locators, loc)
+	loc.loc = nil
+	return nil
+}
+
+// Length returns the length of the referenced LOB in bytes.
+//
+// The length is sent by the server together with the locator, hence
+// Length does not query the server and can be called while the rows
+// the locator was fetched with are still open.
+func (loc *Locator) Length() (int64, error) {
+	if loc.loc == nil {
+		return 0, errors.New("Locator is closed")
+	}
+
+	var length C.CS_BIGINT
+	retval := C.cs_locator(loc.conn.driverCtx.ctx, C.CS_GET, loc.loc, C.CS_LCTR_LOBLEN,
+		unsafe.Pointer(&length), C.sizeof_CS_BIGINT, nil)
+	if retval != C.CS_SUCCEED {
+		return 0, makeError(retval, "Failed to retrieve LOB length")
+	}
+
+	return int64(length), nil
+}
+
+// Bytes returns the value of the locator.
+func (loc *Locator) Bytes() ([]byte, error) {
+	if loc.loc == nil {
+		return nil, errors.New("Locator is closed")
+	}
+
+	buf := make([]byte, locatorBufferSize)
+	var outlen C.CS_INT
+	retval := C.cs_locator(loc.conn.driverCtx.ctx, C.CS_GET, loc.loc, C.CS_LCTR_LOCATOR,
+		unsafe.Pointer(&buf[0]), (C.CS_INT)(len(buf)), &outlen)
+	if retval != C.CS_SUCCEED {
+		return nil, makeError(retval, "Failed to retrieve locator value")
+	}
+
+	return buf[:outlen], nil
+}
+
+// literal returns the locator as a locator_literal expression.
+func (loc *Locator) literal() (string, error) {
+	bs, err := loc.Bytes()
+	if err != nil {
+		return "", err
+	}
+
+	// Depending on the version Client-Library returns the locator
+	// either in its binary or in its character representation.
+	bs = bytes.TrimRight(bs, "\x00")
+	value := string(bs)
+	if !bytes.HasPrefix(bs, []byte("0x")) {
+		value = "0x" + hex.EncodeToString(bs)
+	}
+
+	var typeName string
+	switch loc.Type {
+	case TEXTLOCATOR:
+		typeName = "text_locator"
+	case IMAGELOCATOR:
+		typeName = "image_locator"
+	case UNITEXTLOCATOR:
+		typeName = "unitext_locator"
+	default:
+		return "", fmt.Errorf("Invalid locator type %s", loc.Type)
+	}
+
+	return fmt.Sprintf("locator_literal(%s, %s)", typeName, value), nil
+}
+
+// Substring reads length bytes or characters of the referenced LOB
+// starting at the 1-based offset from the server.
+//
+// The returned value is a string for TEXTLOCATOR and UNITEXTLOCATOR and
+// a []byte for IMAGELOCATOR.
+//
+// Substring sends a query on the connection of the locator and returns
+// an error if the rows the locator was fetched with are still open, as
+// the connection cannot send commands until all results were read.
+func (loc *Locator) Substring(ctx context.Context, offset, length int64) (driver.Value, error) {
+	if loc.rows != nil && loc.rows.cmd != nil {
+		return nil, errors.New("Locator cannot be read while the rows it was fetched with are open")
+	}
+
+	if loc.loc == nil {
+		return nil, errors.New("Locator is closed")
+	}
+
+	literal, err := loc.literal()
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf("select substring(%s, %d, %d)", literal, offset, length)
+	rows, _, err := loc.conn.GenericExec(ctx, query, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading substring of locator: %w", err)
+	}
+	if rows == nil {
+		return nil, errors.New("Received no result reading substring of locator")
+	}
+	defer rows.Close()
+
+	dest := make([]driver.Value, 1)
+	if err := rows.Next(dest); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("Received no rows reading substring of locator")
+		}
+		return nil, fmt.Errorf("Error reading substring of locator: %w", err)
+	}
+
+	return dest[0], nil
+}
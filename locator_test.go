@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestIsLocatorType(t *testing.T) {
+	cases := map[ASEType]bool{
+		TEXTLOCATOR:    true,
+		IMAGELOCATOR:   true,
+		UNITEXTLOCATOR: true,
+		TEXT:           false,
+		IMAGE:          false,
+		VARCHAR:        false,
+	}
+
+	for asetype, expected := range cases {
+		if recv := isLocatorType(asetype); recv != expected {
+			t.Errorf("%s: expected %t, received %t", asetype, expected, recv)
+		}
+	}
+}
+
+func TestLocatorSubstring(t *testing.T) {
+	cases := map[string]struct {
+		loc *Locator
+		err string
+	}{
+		"rows open": {
+			loc: &Locator{Type: TEXTLOCATOR, rows: &Rows{cmd: &Command{}}},
+			err: "rows it was fetched with are open",
+		},
+		"rows closed": {
+			loc: &Locator{Type: TEXTLOCATOR, rows: &Rows{}},
+			err: "closed",
+		},
+		"closed": {
+			loc: &Locator{Type: IMAGELOCATOR},
+			err: "closed",
+		},
+	}
+
+	for title, cas := range cases {
+		t.Run(title, func(t *testing.T) {
+			_, err := cas.loc.Substring(context.Background(), 1, 10)
+			if err == nil || !strings.Contains(err.Error(), cas.err) {
+				t.Errorf("Expected error containing %q, received %v", cas.err, err)
+			}
+		})
+	}
+}
+
+func TestLocatorClosed(t *testing.T) {
+	loc := &Locator{Type: TEXTLOCATOR}
+
+	if err := loc.Close(); err != nil {
+		t.Errorf("Unexpected error closing closed locator: %v", err)
+	}
+
+	if _, err := loc.Length(); err == nil {
+		t.Errorf("Expected error reading length of closed locator")
+	}
+
+	if _, err := loc.Bytes(); err == nil {
+		t.Errorf("Expected error reading value of closed locator")
+	}
+}
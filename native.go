@@ -0,0 +1,255 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/SAP/go-dblib/asetypes"
+)
+
+// nativeConverter converts the values of result columns into idiomatic
+// Go types if the property NativeTypes is set.
+type nativeConverter struct {
+	// location is the location date and time values are
+	// interpreted in.
+	location *time.Location
+	// decimalAsString signals that DECIMAL, NUMERIC and MONEY
+	// values are returned as string instead of *big.Rat.
+	decimalAsString bool
+}
+
+// newNativeConverter returns the nativeConverter configured in info or
+// nil if NativeTypes is not set.
+func newNativeConverter(info *Info) (*nativeConverter, error) {
+	if !info.NativeTypes {
+		return nil, nil
+	}
+
+	conv := &nativeConverter{location: time.UTC}
+
+	if info.Location != "" {
+		loc, err := time.LoadLocation(info.Location)
+		if err != nil {
+			return nil, fmt.Errorf("error loading location %q: %w", info.Location, err)
+		}
+		conv.location = loc
+	}
+
+	switch info.NativeDecimal {
+	case "", "rat":
+	case "string":
+		conv.decimalAsString = true
+	default:
+		return nil, fmt.Errorf("invalid value for native-decimal: %q", info.NativeDecimal)
+	}
+
+	return conv, nil
+}
+
+// goValue converts a value of a column of the passed ASEType as
+// returned by columnValue into its idiomatic Go type.
+func (conv *nativeConverter) goValue(t ASEType, value driver.Value) (driver.Value, error) {
+	switch typed := value.(type) {
+	case uint8:
+		return int64(typed), nil
+	case int16:
+		return int64(typed), nil
+	case int32:
+		return int64(typed), nil
+	case uint16:
+		return uint64(typed), nil
+	case uint32:
+		return uint64(typed), nil
+	case float32:
+		return float64(typed), nil
+	case time.Time:
+		// ASE stores date and time values without a time zone,
+		// the returned value is the same wall clock in location.
+		return time.Date(typed.Year(), typed.Month(), typed.Day(), typed.Hour(),
+			typed.Minute(), typed.Second(), typed.Nanosecond(), conv.location), nil
+	case *asetypes.Decimal:
+		if conv.decimalAsString {
+			return typed.String(), nil
+		}
+
+		rat, ok := new(big.Rat).SetString(typed.String())
+		if !ok {
+			return nil, fmt.Errorf("error converting %s value %s to *big.Rat", t, typed)
+		}
+		return rat, nil
+	default:
+		return value, nil
+	}
+}
+
+// scanType returns the Go type goValue returns for columns of the
+// passed ASEType or nil if values are not converted.
+func (conv *nativeConverter) scanType(t ASEType) reflect.Type {
+	switch t {
+	case TINYINT, SMALLINT, INT, BIGINT, LONG:
+		return reflect.TypeOf(int64(0))
+	case USMALLINT, USHORT, UINT, UBIGINT:
+		return reflect.TypeOf(uint64(0))
+	case REAL:
+		return reflect.TypeOf(float64(0))
+	case DATE, TIME, DATETIME, DATETIME4, BIGDATETIME, BIGTIME:
+		return reflect.TypeOf(time.Time{})
+	case DECIMAL, NUMERIC, MONEY, MONEY4:
+		if conv.decimalAsString {
+			return reflect.TypeOf("")
+		}
+		return reflect.TypeOf(&big.Rat{})
+	default:
+		return nil
+	}
+}
+
+// paramLocation returns the location date and time parameters are
+// converted into or nil if native types are not enabled.
+func (conv *nativeConverter) paramLocation() *time.Location {
+	if conv == nil {
+		return nil
+	}
+	return conv.location
+}
+
+// paramValue converts idiomatic Go values passed for a parameter of the
+// passed ASEType into the type expected by ToDataType().ConvertValue.
+//
+// precision and scale are the precision and scale of DECIMAL and
+// NUMERIC parameters. If loc is not nil time.Time values are converted
+// into the wall clock in loc.
+func paramValue(t ASEType, precision, scale int, loc *time.Location, value driver.Value) (driver.Value, error) {
+	switch t {
+	case TINYINT:
+		return integerParam(value, 0, math.MaxUint8)
+	case SMALLINT:
+		return integerParam(value, math.MinInt16, math.MaxInt16)
+	case INT:
+		return integerParam(value, math.MinInt32, math.MaxInt32)
+	case BIGINT, LONG:
+		return integerParam(value, math.MinInt64, math.MaxInt64)
+	case USMALLINT, USHORT:
+		return unsignedParam(value, math.MaxUint16)
+	case UINT:
+		return unsignedParam(value, math.MaxUint32)
+	case UBIGINT:
+		return unsignedParam(value, math.MaxUint64)
+	case DECIMAL, NUMERIC:
+		return decimalParam(value, precision, scale)
+	case MONEY:
+		return decimalParam(value, asetypes.ASEMoneyPrecision, asetypes.ASEMoneyScale)
+	case MONEY4:
+		return decimalParam(value, asetypes.ASEShortMoneyPrecision, asetypes.ASEShortMoneyScale)
+	case DATE, TIME, DATETIME, DATETIME4, BIGDATETIME, BIGTIME:
+		ts, ok := value.(time.Time)
+		if !ok || loc == nil {
+			return value, nil
+		}
+
+		ts = ts.In(loc)
+		return time.Date(ts.Year(), ts.Month(), ts.Day(), ts.Hour(), ts.Minute(),
+			ts.Second(), ts.Nanosecond(), time.UTC), nil
+	default:
+		return value, nil
+	}
+}
+
+// integerParam returns value as int64 if it is an integer within the
+// bounds.
+func integerParam(value driver.Value, min, max int64) (driver.Value, error) {
+	sv := reflect.ValueOf(value)
+	switch sv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if i := sv.Int(); i < min || i > max {
+			return nil, fmt.Errorf("value %d is out of range [%d, %d]", i, min, max)
+		}
+		return sv.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if u := sv.Uint(); u > uint64(max) {
+			return nil, fmt.Errorf("value %d is out of range [%d, %d]", u, min, max)
+		}
+		return int64(sv.Uint()), nil
+	default:
+		return value, nil
+	}
+}
+
+// unsignedParam returns value as uint64 if it is a non-negative integer
+// within the bound.
+func unsignedParam(value driver.Value, max uint64) (driver.Value, error) {
+	sv := reflect.ValueOf(value)
+	switch sv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if i := sv.Int(); i < 0 || uint64(i) > max {
+			return nil, fmt.Errorf("value %d is out of range [0, %d]", i, max)
+		}
+		return uint64(sv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if u := sv.Uint(); u > max {
+			return nil, fmt.Errorf("value %d is out of range [0, %d]", u, max)
+		}
+		return sv.Uint(), nil
+	default:
+		return value, nil
+	}
+}
+
+// decimalParam returns value as *asetypes.Decimal with the passed
+// precision and scale. Values which cannot be represented exactly with
+// the scale are refused.
+func decimalParam(value driver.Value, precision, scale int) (driver.Value, error) {
+	rat := new(big.Rat)
+	switch typed := value.(type) {
+	case *asetypes.Decimal:
+		return typed, nil
+	case *big.Rat:
+		rat.Set(typed)
+	case string:
+		if _, ok := rat.SetString(typed); !ok {
+			return nil, fmt.Errorf("cannot parse %q as decimal", typed)
+		}
+	case float64:
+		// Use the shortest representation of the float, which is
+		// what users expect to be passed.
+		if _, ok := rat.SetString(strconv.FormatFloat(typed, 'f', -1, 64)); !ok {
+			return nil, fmt.Errorf("cannot convert %v to decimal", typed)
+		}
+	default:
+		i, err := integerParam(value, math.MinInt64, math.MaxInt64)
+		if err != nil {
+			return nil, err
+		}
+
+		i64, ok := i.(int64)
+		if !ok {
+			return nil, fmt.Errorf("cannot convert %T to decimal", value)
+		}
+		rat.SetInt64(i64)
+	}
+
+	s := rat.FloatString(scale)
+	if exact, _ := new(big.Rat).SetString(s); exact.Cmp(rat) != 0 {
+		return nil, fmt.Errorf("value %s cannot be represented with scale %d", rat.RatString(), scale)
+	}
+
+	dec, err := asetypes.NewDecimalString(precision, scale, s)
+	if err != nil {
+		return nil, fmt.Errorf("error creating decimal: %w", err)
+	}
+
+	if digits := len(new(big.Int).Abs(dec.Int()).String()); digits > precision {
+		return nil, fmt.Errorf("value %s exceeds precision %d", s, precision)
+	}
+
+	return dec, nil
+}
@@ -0,0 +1,183 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"database/sql/driver"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/SAP/go-dblib/asetypes"
+)
+
+func TestNativeGoValue(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("Location unavailable: %v", err)
+	}
+
+	dec, err := asetypes.NewDecimalString(10, 2, "-12.34")
+	if err != nil {
+		t.Fatalf("Error creating decimal: %v", err)
+	}
+
+	ts := time.Date(2020, time.March, 4, 5, 6, 7, 0, time.UTC)
+
+	cases := map[string]struct {
+		conv     *nativeConverter
+		t        ASEType
+		value    driver.Value
+		expected driver.Value
+	}{
+		"tinyint": {
+			&nativeConverter{location: time.UTC}, TINYINT, uint8(255), int64(255),
+		},
+		"smallint": {
+			&nativeConverter{location: time.UTC}, SMALLINT, int16(-5), int64(-5),
+		},
+		"uint": {
+			&nativeConverter{location: time.UTC}, UINT, uint32(5), uint64(5),
+		},
+		"real": {
+			&nativeConverter{location: time.UTC}, REAL, float32(0.5), float64(0.5),
+		},
+		"datetime utc": {
+			&nativeConverter{location: time.UTC}, DATETIME, ts, ts,
+		},
+		"datetime location": {
+			&nativeConverter{location: berlin}, DATETIME, ts,
+			time.Date(2020, time.March, 4, 5, 6, 7, 0, berlin),
+		},
+		"decimal rat": {
+			&nativeConverter{location: time.UTC}, DECIMAL, dec, big.NewRat(-1234, 100),
+		},
+		"decimal string": {
+			&nativeConverter{location: time.UTC, decimalAsString: true}, DECIMAL, dec, "-12.34",
+		},
+		"varchar": {
+			&nativeConverter{location: time.UTC}, VARCHAR, "abc", "abc",
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			val, err := tc.conv.goValue(tc.t, tc.value)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			switch expected := tc.expected.(type) {
+			case *big.Rat:
+				if rat, ok := val.(*big.Rat); !ok || rat.Cmp(expected) != 0 {
+					t.Errorf("Expected %v, received %v (%T)", expected, val, val)
+				}
+			case time.Time:
+				if ts, ok := val.(time.Time); !ok || !ts.Equal(expected) || ts.Location() != expected.Location() {
+					t.Errorf("Expected %v, received %v", expected, val)
+				}
+			default:
+				if val != tc.expected {
+					t.Errorf("Expected %v (%T), received %v (%T)", tc.expected, tc.expected, val, val)
+				}
+			}
+
+			if st := tc.conv.scanType(tc.t); st != nil && reflect.TypeOf(val) != st {
+				t.Errorf("Scan type %s does not match value type %T", st, val)
+			}
+		})
+	}
+}
+
+func TestParamValue(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("Location unavailable: %v", err)
+	}
+
+	cases := map[string]struct {
+		t                ASEType
+		precision, scale int
+		loc              *time.Location
+		value            driver.Value
+		expected         driver.Value
+	}{
+		"tinyint from uint": {TINYINT, 0, 0, nil, uint(200), int64(200)},
+		"int from uint64":   {INT, 0, 0, nil, uint64(5), int64(5)},
+		"uint from int":     {UINT, 0, 0, nil, int(5), uint64(5)},
+		"ubigint from uint": {UBIGINT, 0, 0, nil, uint64(1 << 63), uint64(1 << 63)},
+		"decimal from rat":  {DECIMAL, 10, 2, nil, big.NewRat(1, 4), big.NewRat(1, 4)},
+		"decimal from str":  {NUMERIC, 10, 4, nil, "1.5", big.NewRat(3, 2)},
+		"decimal from int":  {DECIMAL, 10, 2, nil, int64(-3), big.NewRat(-3, 1)},
+		"decimal from flt":  {DECIMAL, 10, 2, nil, 0.1, big.NewRat(1, 10)},
+		"money from rat":    {MONEY, 0, 0, nil, big.NewRat(5, 2), big.NewRat(5, 2)},
+		"datetime":          {DATETIME, 0, 0, berlin, time.Date(2020, time.January, 1, 12, 0, 0, 0, time.UTC), time.Date(2020, time.January, 1, 13, 0, 0, 0, time.UTC)},
+		"datetime no loc":   {DATETIME, 0, 0, nil, time.Date(2020, time.January, 1, 12, 0, 0, 0, berlin), time.Date(2020, time.January, 1, 12, 0, 0, 0, berlin)},
+		"varchar":           {VARCHAR, 0, 0, nil, "abc", "abc"},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			val, err := paramValue(tc.t, tc.precision, tc.scale, tc.loc, tc.value)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if _, err := tc.t.ToDataType().ConvertValue(val); err != nil {
+				t.Errorf("Converted value is not accepted by ConvertValue: %v", err)
+			}
+
+			if dec, ok := val.(*asetypes.Decimal); ok {
+				rat, _ := new(big.Rat).SetString(dec.String())
+				if rat.Cmp(tc.expected.(*big.Rat)) != 0 {
+					t.Errorf("Expected %v, received %v", tc.expected, dec)
+				}
+				return
+			}
+
+			if ts, ok := val.(time.Time); ok {
+				if !ts.Equal(tc.expected.(time.Time)) {
+					t.Errorf("Expected %v, received %v", tc.expected, ts)
+				}
+				return
+			}
+
+			if val != tc.expected {
+				t.Errorf("Expected %v (%T), received %v (%T)", tc.expected, tc.expected, val, val)
+			}
+		})
+	}
+}
+
+func TestParamValueInvalid(t *testing.T) {
+	cases := map[string]struct {
+		t                ASEType
+		precision, scale int
+		value            driver.Value
+	}{
+		"tinyint negative":  {TINYINT, 0, 0, int(-1)},
+		"smallint overflow": {SMALLINT, 0, 0, int(1 << 15)},
+		"int from uint64":   {INT, 0, 0, uint64(1 << 31)},
+		"bigint from uint":  {BIGINT, 0, 0, uint64(1 << 63)},
+		"uint negative":     {UINT, 0, 0, int(-1)},
+		"usmallint":         {USMALLINT, 0, 0, uint(1 << 16)},
+		"decimal scale":     {DECIMAL, 10, 2, big.NewRat(1, 3)},
+		"decimal digits":    {DECIMAL, 10, 2, "0.125"},
+		"decimal invalid":   {DECIMAL, 10, 2, "abc"},
+		"decimal precision": {DECIMAL, 4, 2, "123.45"},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			if _, err := paramValue(tc.t, tc.precision, tc.scale, nil, tc.value); err == nil {
+				t.Errorf("Expected error converting %v for %s", tc.value, tc.t)
+			}
+		})
+	}
+}
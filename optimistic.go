@@ -0,0 +1,139 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// msgNumberRowChanged is the number of the server message sent when
+// tsequal detects that a row was modified.
+const msgNumberRowChanged = 532
+
+// ErrRowChanged is returned if a guarded update failed because the row
+// was modified or deleted by another session after its timestamp was
+// read.
+var ErrRowChanged = errors.New("row was changed by another session")
+
+// ErrRowNotFound is returned if a guarded update affected no rows
+// because no row matched its condition, e.g. because the row was
+// deleted.
+var ErrRowNotFound = errors.New("row not found")
+
+// ReadWithTimestamp executes query, which must return a single row
+// including a column of the type timestamp. The values of the row and
+// the value of the timestamp column are returned.
+func (conn *Connection) ReadWithTimestamp(ctx context.Context, query string, args []driver.NamedValue) ([]driver.Value, Timestamp, error) {
+	var ts Timestamp
+
+	driverRows, _, err := conn.GenericExec(ctx, query, args)
+	if err != nil {
+		return nil, ts, err
+	}
+
+	rows, ok := asRows(driverRows)
+	if !ok {
+		return nil, ts, errors.New("Query returned no result set")
+	}
+	defer rows.Close()
+
+	tsIndex := -1
+	for i, t := range rows.colASEType {
+		if t != TIMESTAMP {
+			continue
+		}
+
+		if tsIndex != -1 {
+			return nil, ts, errors.New("Query returned more than one timestamp column")
+		}
+		tsIndex = i
+	}
+
+	if tsIndex == -1 {
+		return nil, ts, errors.New("Query returned no timestamp column")
+	}
+
+	values := make([]driver.Value, rows.numCols)
+	if err := rows.Next(values); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, ts, errors.New("Query returned no rows")
+		}
+		return nil, ts, err
+	}
+
+	if err := rows.Next(make([]driver.Value, rows.numCols)); !errors.Is(err, io.EOF) {
+		if err != nil {
+			return nil, ts, err
+		}
+		return nil, ts, errors.New("Query returned more than one row")
+	}
+
+	ts, ok = values[tsIndex].(Timestamp)
+	if !ok {
+		return nil, ts, fmt.Errorf("Query returned timestamp column value of type %T", values[tsIndex])
+	}
+
+	return values, ts, nil
+}
+
+// GuardedUpdate describes an update of a single row which only
+// succeeds if the row was not modified since its timestamp was read.
+type GuardedUpdate struct {
+	// Table is the table to update.
+	Table string
+	// Set is the set clause of the update, e.g. "name = ?".
+	Set string
+	// Where is the condition identifying the row, e.g. "id = ?".
+	Where string
+	// TimestampColumn is the name of the timestamp column. If empty
+	// "timestamp" is used.
+	TimestampColumn string
+	// Timestamp is the value of the timestamp column when the row
+	// was read.
+	Timestamp Timestamp
+}
+
+// ExecGuarded executes the update with the passed arguments for the
+// placeholders in Set and Where.
+//
+// The update is guarded by tsequal - if the row was modified since
+// the timestamp was read an error wrapping ErrRowChanged is returned.
+// If no row matches Where, e.g. because the row was deleted, an error
+// wrapping ErrRowNotFound is returned.
+//
+// Table, Set and Where are inserted into the SQL text as-is and must
+// not contain untrusted input.
+func (conn *Connection) ExecGuarded(ctx context.Context, update GuardedUpdate, args []driver.NamedValue) (driver.Result, error) {
+	column := update.TimestampColumn
+	if column == "" {
+		column = "timestamp"
+	}
+
+	query := fmt.Sprintf("update %s set %s where (%s) and tsequal(%s, %s)",
+		update.Table, update.Set, update.Where, column, update.Timestamp)
+
+	_, result, err := conn.GenericExec(ctx, query, args)
+	return guardedResult(result, err)
+}
+
+// guardedResult returns the result of a guarded update. err is returned
+// as-is, it wraps ErrRowChanged if the server reported a changed
+// timestamp. An update without affected rows did not find the row.
+func guardedResult(result driver.Result, err error) (driver.Result, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	res, ok := asResult(result)
+	if !ok || res.rowsAffected == 0 {
+		return nil, fmt.Errorf("%w: no rows affected", ErrRowNotFound)
+	}
+
+	return res, nil
+}
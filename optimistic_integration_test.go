@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// +build integration
+
+package ase
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestExecGuarded(t *testing.T) {
+	withConnection(t, nil, func(conn *Connection) {
+		ctx := context.Background()
+		if _, err := conn.ExecContext(ctx, "create table #guarded (id int, a int, timestamp)", nil); err != nil {
+			t.Fatalf("Error creating table: %v", err)
+		}
+
+		if _, err := conn.ExecContext(ctx, "insert #guarded (id, a) values (1, 1)", nil); err != nil {
+			t.Fatalf("Error inserting row: %v", err)
+		}
+
+		_, ts, err := conn.ReadWithTimestamp(ctx, "select id, a, timestamp from #guarded where id = 1", nil)
+		if err != nil {
+			t.Fatalf("Error reading row: %v", err)
+		}
+
+		update := GuardedUpdate{Table: "#guarded", Set: "a = a + 1", Where: "id = 1", Timestamp: ts}
+		if _, err := conn.ExecGuarded(ctx, update, nil); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		// The timestamp changed with the update.
+		if _, err := conn.ExecGuarded(ctx, update, nil); !errors.Is(err, ErrRowChanged) {
+			t.Errorf("Expected error wrapping %v, received %v", ErrRowChanged, err)
+		}
+
+		if _, err := conn.ExecContext(ctx, "delete #guarded where id = 1", nil); err != nil {
+			t.Fatalf("Error deleting row: %v", err)
+		}
+
+		if _, err := conn.ExecGuarded(ctx, update, nil); !errors.Is(err, ErrRowNotFound) {
+			t.Errorf("Expected error wrapping %v, received %v", ErrRowNotFound, err)
+		}
+	})
+}
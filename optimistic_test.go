@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"database/sql/driver"
+	"errors"
+	"testing"
+)
+
+func TestCommandError(t *testing.T) {
+	errFailed := errors.New("Command failed")
+
+	cases := map[string]struct {
+		messages []ServerMessage
+		sentinel error
+	}{
+		"no messages": {
+			messages: nil,
+		},
+		"unrelated message": {
+			messages: []ServerMessage{{MsgNumber: 208, Severity: 16, Text: "t1 not found."}},
+		},
+		"row changed": {
+			messages: []ServerMessage{{MsgNumber: msgNumberRowChanged, Severity: 16, Text: "The timestamp has changed."}},
+			sentinel: ErrRowChanged,
+		},
+		"row changed after other message": {
+			messages: []ServerMessage{
+				{MsgNumber: 208, Severity: 16},
+				{MsgNumber: 532, Severity: 16},
+			},
+			sentinel: ErrRowChanged,
+		},
+	}
+
+	for title, cas := range cases {
+		t.Run(title, func(t *testing.T) {
+			conn := &Connection{errorMessages: cas.messages}
+
+			err := conn.commandError(errFailed)
+			if cas.sentinel == nil {
+				if err != errFailed {
+					t.Errorf("Expected error to be returned as-is, received %v", err)
+				}
+				return
+			}
+
+			if !errors.Is(err, cas.sentinel) {
+				t.Errorf("Expected error wrapping %v, received %v", cas.sentinel, err)
+			}
+
+			if errors.Is(err, ErrRowNotFound) {
+				t.Errorf("Expected error not to wrap %v", ErrRowNotFound)
+			}
+		})
+	}
+}
+
+func TestGuardedResult(t *testing.T) {
+	changed := (&Connection{
+		errorMessages: []ServerMessage{{MsgNumber: msgNumberRowChanged, Severity: 16}},
+	}).commandError(errors.New("Command failed"))
+
+	var typedNil *Result
+
+	cases := map[string]struct {
+		result   driver.Result
+		err      error
+		sentinel error
+	}{
+		"row changed":   {nil, changed, ErrRowChanged},
+		"no rows":       {&Result{rowsAffected: 0}, nil, ErrRowNotFound},
+		"no result":     {typedNil, nil, ErrRowNotFound},
+		"row updated":   {&Result{rowsAffected: 1}, nil, nil},
+		"other failure": {nil, errors.New("failure"), nil},
+	}
+
+	for title, cas := range cases {
+		t.Run(title, func(t *testing.T) {
+			result, err := guardedResult(cas.result, cas.err)
+
+			switch {
+			case cas.sentinel != nil:
+				if !errors.Is(err, cas.sentinel) {
+					t.Errorf("Expected error wrapping %v, received %v", cas.sentinel, err)
+				}
+			case cas.err != nil:
+				if err != cas.err {
+					t.Errorf("Expected error %v, received %v", cas.err, err)
+				}
+			default:
+				if err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+				if result != cas.result {
+					t.Errorf("Expected result %v, received %v", cas.result, result)
+				}
+			}
+
+			if cas.sentinel == ErrRowChanged && errors.Is(err, ErrRowNotFound) {
+				t.Errorf("Expected changed row not to be reported as not found")
+			}
+		})
+	}
+}
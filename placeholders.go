@@ -0,0 +1,354 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+)
+
+// parsedQuery is a query with its placeholders replaced by the question
+// marks expected by dynamic SQL.
+type parsedQuery struct {
+	// query is the query passed to ct_dynamic.
+	query string
+	// numParams is the number of placeholders.
+	numParams int
+	// placeholders contains the offsets of the placeholders in query.
+	placeholders []int
+	// paramNames contains the name of each placeholder if the query
+	// uses named placeholders, nil otherwise. A name is contained
+	// once for each of its occurrences.
+	paramNames []string
+	// inserts is set if the query contains insert or merge statements
+	// and executes if it executes procedures, both may generate
+	// identity values. Statements in the body of a created procedure,
+	// function or trigger are not considered.
+	inserts, executes bool
+}
+
+// statementKeywords are keywords starting a statement. They end the
+// variable list of a declare and the arguments of an execute statement.
+var statementKeywords = map[string]bool{
+	"alter":      true,
+	"begin":      true,
+	"break":      true,
+	"close":      true,
+	"commit":     true,
+	"continue":   true,
+	"create":     true,
+	"deallocate": true,
+	"declare":    true,
+	"delete":     true,
+	"drop":       true,
+	"else":       true,
+	"exec":       true,
+	"execute":    true,
+	"fetch":      true,
+	"goto":       true,
+	"grant":      true,
+	"if":         true,
+	"insert":     true,
+	"open":       true,
+	"print":      true,
+	"raiserror":  true,
+	"readtext":   true,
+	"return":     true,
+	"revoke":     true,
+	"rollback":   true,
+	"save":       true,
+	"select":     true,
+	"set":        true,
+	"truncate":   true,
+	"update":     true,
+	"waitfor":    true,
+	"while":      true,
+	"writetext":  true,
+}
+
+// bodyObjects are the objects whose create statement contains a body
+// with its own parameters and variables, which extends to the end of
+// the query.
+var bodyObjects = map[string]bool{
+	"function":  true,
+	"proc":      true,
+	"procedure": true,
+	"trigger":   true,
+}
+
+// parseQuery finds the placeholders in query, skipping string literals,
+// quoted identifiers and comments.
+//
+// Placeholders are either positional as question marks or, if named is
+// set, named as @name. Names are only placeholders if the query has no
+// positional placeholders. Local variables declared in the query,
+// global variables (@@name) and the parameters and variables in the
+// body of a created procedure, function or trigger are not
+// placeholders.
+func parseQuery(query string, named bool) (*parsedQuery, error) {
+	var (
+		out        strings.Builder
+		positional []int
+		names      []string
+		namesPos   []int
+
+		// declared contains the variables declared in the query.
+		declared = map[string]bool{}
+		// inDeclare is set while reading the variable list of a
+		// declare statement.
+		inDeclare bool
+		// inExec is set while reading the arguments of an execute
+		// statement.
+		inExec bool
+		// inBody is set after the start of the body of a created
+		// procedure, function or trigger.
+		inBody bool
+		// lastWord is the previous keyword or identifier.
+		lastWord string
+		depth    int
+
+		inserts, executes bool
+	)
+
+	for i := 0; i < len(query); {
+		c := query[i]
+
+		switch {
+		case c == '\'' || c == '"':
+			end, err := skipQuoted(query, i, c)
+			if err != nil {
+				return nil, err
+			}
+			out.WriteString(query[i:end])
+			i = end
+		case c == '[':
+			end, err := skipQuoted(query, i, ']')
+			if err != nil {
+				return nil, fmt.Errorf("unterminated quoted identifier at offset %d", i)
+			}
+			out.WriteString(query[i:end])
+			i = end
+		case c == '-' && strings.HasPrefix(query[i:], "--"):
+			end := strings.IndexByte(query[i:], '\n')
+			if end < 0 {
+				end = len(query) - i
+			}
+			out.WriteString(query[i : i+end])
+			i += end
+		case c == '/' && strings.HasPrefix(query[i:], "/*"):
+			end := strings.Index(query[i+2:], "*/")
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated comment at offset %d", i)
+			}
+			out.WriteString(query[i : i+2+end+2])
+			i += 2 + end + 2
+		case c == '?':
+			positional = append(positional, out.Len())
+			out.WriteByte(c)
+			i++
+		case c == '@':
+			end := i + 1
+			for end < len(query) && query[end] == '@' {
+				end++
+			}
+			for end < len(query) && isIdentifierChar(query[end]) {
+				end++
+			}
+
+			name := query[i+1 : end]
+			switch {
+			case !named, inBody:
+				// Variables or parameters of a body.
+			case strings.HasPrefix(name, "@"), name == "":
+				// Global variable or a lone @.
+			case inDeclare && depth == 0:
+				declared[strings.ToLower(name)] = true
+			case inExec && isAssignment(query[end:]):
+				// Name of a procedure parameter.
+			default:
+				names = append(names, name)
+				namesPos = append(namesPos, out.Len())
+			}
+
+			out.WriteString(query[i:end])
+			i = end
+		case isIdentifierStart(c):
+			end := i + 1
+			for end < len(query) && isIdentifierChar(query[end]) {
+				end++
+			}
+
+			word := strings.ToLower(query[i:end])
+			if bodyObjects[word] && (lastWord == "create" || lastWord == "replace") {
+				inBody = true
+			}
+
+			if statementKeywords[word] {
+				inDeclare = word == "declare"
+				inExec = word == "exec" || word == "execute"
+				depth = 0
+			}
+
+			if !inBody {
+				inserts = inserts || word == "insert" || word == "merge"
+				executes = executes || inExec
+			}
+			lastWord = word
+
+			out.WriteString(query[i:end])
+			i = end
+		default:
+			switch c {
+			case '(':
+				depth++
+			case ')':
+				depth--
+			case ';':
+				inDeclare = false
+				inExec = false
+			}
+			out.WriteByte(c)
+			i++
+		}
+	}
+
+	// Remove declared variables, which may be referenced before
+	// being declared in batches.
+	var params []string
+	var positions []int
+	for i, name := range names {
+		if declared[strings.ToLower(name)] {
+			continue
+		}
+		params = append(params, name)
+		positions = append(positions, namesPos[i])
+	}
+
+	// Names in queries with positional placeholders are variables.
+	if len(params) == 0 || len(positional) > 0 {
+		return &parsedQuery{
+			query:        query,
+			numParams:    len(positional),
+			placeholders: positional,
+			inserts:      inserts,
+			executes:     executes,
+		}, nil
+	}
+
+	// Replace the named placeholders with question marks.
+	parsed := out.String()
+	var rewritten strings.Builder
+	placeholders := make([]int, len(positions))
+	last := 0
+	for i, pos := range positions {
+		rewritten.WriteString(parsed[last:pos])
+		placeholders[i] = rewritten.Len()
+		rewritten.WriteByte('?')
+		last = pos + 1 + len(params[i])
+	}
+	rewritten.WriteString(parsed[last:])
+
+	return &parsedQuery{
+		query:        rewritten.String(),
+		numParams:    len(params),
+		placeholders: placeholders,
+		paramNames:   params,
+		inserts:      inserts,
+		executes:     executes,
+	}, nil
+}
+
+// hasNamedArgs returns true if one of args was passed with a name.
+func hasNamedArgs(args []driver.NamedValue) bool {
+	for _, arg := range args {
+		if arg.Name != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// skipQuoted returns the offset after the string literal or quoted
+// identifier starting at offset start and ending with quote. Quotes are
+// escaped by doubling them, e.g. [a]]b] is the identifier a]b.
+func skipQuoted(query string, start int, quote byte) (int, error) {
+	for i := start + 1; i < len(query); i++ {
+		if query[i] != quote {
+			continue
+		}
+
+		if i+1 < len(query) && query[i+1] == quote {
+			i++
+			continue
+		}
+
+		return i + 1, nil
+	}
+
+	return 0, fmt.Errorf("unterminated literal starting at offset %d", start)
+}
+
+// isAssignment returns true if s starts with an equals sign after
+// optional whitespace.
+func isAssignment(s string) bool {
+	s = strings.TrimLeft(s, " \t\r\n")
+	return strings.HasPrefix(s, "=")
+}
+
+func isIdentifierStart(c byte) bool {
+	return c == '_' || c == '#' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c >= 0x80
+}
+
+func isIdentifierChar(c byte) bool {
+	return isIdentifierStart(c) || c == '$' || (c >= '0' && c <= '9')
+}
+
+// mapNamedArgs orders named arguments by the names of the placeholders
+// and sets their ordinals accordingly. Arguments for queries with
+// positional placeholders are returned as-is and bound by their
+// ordinals, even if they were passed with a name.
+func mapNamedArgs(paramNames []string, args []driver.NamedValue) ([]driver.NamedValue, error) {
+	if paramNames == nil {
+		return args, nil
+	}
+
+	byName := make(map[string]driver.NamedValue, len(args))
+	for _, arg := range args {
+		if arg.Name == "" {
+			return nil, fmt.Errorf("positional argument %d passed for query with named placeholders", arg.Ordinal)
+		}
+		byName[strings.ToLower(strings.TrimPrefix(arg.Name, "@"))] = arg
+	}
+
+	mapped := make([]driver.NamedValue, len(paramNames))
+	for i, name := range paramNames {
+		arg, ok := byName[strings.ToLower(name)]
+		if !ok {
+			return nil, fmt.Errorf("missing argument for placeholder @%s", name)
+		}
+
+		mapped[i] = driver.NamedValue{Name: arg.Name, Ordinal: i + 1, Value: arg.Value}
+	}
+
+	return mapped, nil
+}
+
+// paramIndex returns the index of the first placeholder the passed
+// argument is bound to.
+func paramIndex(paramNames []string, arg *driver.NamedValue) (int, error) {
+	if paramNames == nil || arg.Name == "" {
+		return arg.Ordinal - 1, nil
+	}
+
+	name := strings.TrimPrefix(arg.Name, "@")
+	for i, paramName := range paramNames {
+		if strings.EqualFold(paramName, name) {
+			return i, nil
+		}
+	}
+
+	return 0, fmt.Errorf("no placeholder named @%s", name)
+}
@@ -0,0 +1,286 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"testing"
+)
+
+func TestParseQuery(t *testing.T) {
+	cases := map[string]struct {
+		query     string
+		expected  string
+		numParams int
+		names     []string
+	}{
+		"no placeholders": {
+			"select 1", "select 1", 0, nil,
+		},
+		"positional": {
+			"select * from t where a = ? and b = ?",
+			"select * from t where a = ? and b = ?", 2, nil,
+		},
+		"string literal": {
+			"select '?', 'it''s ?' from t where a = ?",
+			"select '?', 'it''s ?' from t where a = ?", 1, nil,
+		},
+		"double quoted": {
+			`select "a?""b" from t where a = ?`,
+			`select "a?""b" from t where a = ?`, 1, nil,
+		},
+		"bracket identifier": {
+			"select [a?b] from t where a = ?",
+			"select [a?b] from t where a = ?", 1, nil,
+		},
+		"line comment": {
+			"select a -- why?\nfrom t where a = ?",
+			"select a -- why?\nfrom t where a = ?", 1, nil,
+		},
+		"line comment at end": {
+			"select a from t -- why?",
+			"select a from t -- why?", 0, nil,
+		},
+		"block comment": {
+			"select /* ? @x */ a from t where a = ?",
+			"select /* ? @x */ a from t where a = ?", 1, nil,
+		},
+		"named": {
+			"select * from t where a = @a and b = @B",
+			"select * from t where a = ? and b = ?", 2, []string{"a", "B"},
+		},
+		"named repeated": {
+			"select * from t where a = @id or b = @id",
+			"select * from t where a = ? or b = ?", 2, []string{"id", "id"},
+		},
+		"named in literal": {
+			"select '@a' from t where a = @b",
+			"select '@a' from t where a = ?", 1, []string{"b"},
+		},
+		"global variable": {
+			"select @@identity, @@rowcount",
+			"select @@identity, @@rowcount", 0, nil,
+		},
+		"global variable and named": {
+			"select @@spid where 1 = @one",
+			"select @@spid where 1 = ?", 1, []string{"one"},
+		},
+		"declared variable": {
+			"declare @x int, @y varchar(10) select @x = a from t where b = @b",
+			"declare @x int, @y varchar(10) select @x = a from t where b = ?", 1, []string{"b"},
+		},
+		"declared variable positional": {
+			"declare @x int; select @x = a from t where b = ?",
+			"declare @x int; select @x = a from t where b = ?", 1, nil,
+		},
+		"procedure parameter": {
+			"exec sp_test @p1 = @value, @p2 = 'x'",
+			"exec sp_test @p1 = ?, @p2 = 'x'", 1, []string{"value"},
+		},
+		"procedure parameter positional": {
+			"execute sp_test @p1 = ?",
+			"execute sp_test @p1 = ?", 1, nil,
+		},
+		"identifier chars": {
+			"select * from t where a = @a_1$#",
+			"select * from t where a = ?", 1, []string{"a_1$#"},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			parsed, err := parseQuery(tc.query, true)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if parsed.query != tc.expected {
+				t.Errorf("Expected query %q, received %q", tc.expected, parsed.query)
+			}
+
+			if parsed.numParams != tc.numParams {
+				t.Errorf("Expected %d parameters, received %d", tc.numParams, parsed.numParams)
+			}
+
+			if !reflect.DeepEqual(parsed.paramNames, tc.names) {
+				t.Errorf("Expected names %v, received %v", tc.names, parsed.paramNames)
+			}
+
+			if len(parsed.placeholders) != parsed.numParams {
+				t.Fatalf("Expected %d placeholder offsets, received %d", parsed.numParams, len(parsed.placeholders))
+			}
+
+			for _, offset := range parsed.placeholders {
+				if parsed.query[offset] != '?' {
+					t.Errorf("Expected placeholder at offset %d, found %q", offset, parsed.query[offset])
+				}
+			}
+		})
+	}
+}
+
+func TestParseQueryNamed(t *testing.T) {
+	cases := map[string]struct {
+		query     string
+		named     bool
+		expected  string
+		numParams int
+		names     []string
+	}{
+		"names without named arguments": {
+			"select * from t where a = @a", false,
+			"select * from t where a = @a", 0, nil,
+		},
+		"variables without named arguments": {
+			"declare @x int select @x = ? ", false,
+			"declare @x int select @x = ? ", 1, nil,
+		},
+		"positional with named arguments": {
+			"select * from t where a = ? and b = @v", true,
+			"select * from t where a = ? and b = @v", 1, nil,
+		},
+		"declared after print": {
+			"declare @x int print @y", true,
+			"declare @x int print ?", 1, []string{"y"},
+		},
+		"declared after raiserror": {
+			"declare @x int raiserror 20001 @y", true,
+			"declare @x int raiserror 20001 ?", 1, []string{"y"},
+		},
+		"declared cursor": {
+			"declare c cursor for select a from t where b = @b", true,
+			"declare c cursor for select a from t where b = ?", 1, []string{"b"},
+		},
+		"declared before use": {
+			"declare @x int select @x = a from t where b = @b select @x", true,
+			"declare @x int select @x = a from t where b = ? select @x", 1, []string{"b"},
+		},
+		"procedure body": {
+			"create procedure p @a int as select @a, @b", true,
+			"create procedure p @a int as select @a, @b", 0, nil,
+		},
+		"proc body": {
+			"CREATE PROC p (@a int) AS update t set a = @a", true,
+			"CREATE PROC p (@a int) AS update t set a = @a", 0, nil,
+		},
+		"replaced procedure body": {
+			"create or replace procedure p @a int as select @a", true,
+			"create or replace procedure p @a int as select @a", 0, nil,
+		},
+		"trigger body": {
+			"create trigger tr on t for insert as declare @x int select @x = @@rowcount", true,
+			"create trigger tr on t for insert as declare @x int select @x = @@rowcount", 0, nil,
+		},
+		"create table": {
+			"create table #t (a int) insert #t values (@a)", true,
+			"create table #t (a int) insert #t values (?)", 1, []string{"a"},
+		},
+		"escaped bracket": {
+			"select [a]]?@x] from t where a = ?", true,
+			"select [a]]?@x] from t where a = ?", 1, nil,
+		},
+		"escaped bracket named": {
+			"select [a]]@b] from t where a = @a", true,
+			"select [a]]@b] from t where a = ?", 1, []string{"a"},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			parsed, err := parseQuery(tc.query, tc.named)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if parsed.query != tc.expected {
+				t.Errorf("Expected query %q, received %q", tc.expected, parsed.query)
+			}
+
+			if parsed.numParams != tc.numParams {
+				t.Errorf("Expected %d parameters, received %d", tc.numParams, parsed.numParams)
+			}
+
+			if !reflect.DeepEqual(parsed.paramNames, tc.names) {
+				t.Errorf("Expected names %v, received %v", tc.names, parsed.paramNames)
+			}
+		})
+	}
+}
+
+func TestHasNamedArgs(t *testing.T) {
+	if hasNamedArgs(nil) {
+		t.Errorf("Expected no named arguments for nil")
+	}
+
+	if hasNamedArgs([]driver.NamedValue{{Ordinal: 1, Value: 1}}) {
+		t.Errorf("Expected no named arguments for positional arguments")
+	}
+
+	if !hasNamedArgs([]driver.NamedValue{{Ordinal: 1, Value: 1}, {Name: "b", Ordinal: 2, Value: 2}}) {
+		t.Errorf("Expected named arguments")
+	}
+}
+
+func TestParseQueryInvalid(t *testing.T) {
+	cases := map[string]string{
+		"unterminated literal":    "select 'abc",
+		"unterminated double":     `select "abc`,
+		"unterminated identifier": "select [abc",
+		"unterminated comment":    "select /* abc",
+		"unterminated escape":     "select [a]]",
+	}
+
+	for name, query := range cases {
+		query := query
+		t.Run(name, func(t *testing.T) {
+			if _, err := parseQuery(query, true); err == nil {
+				t.Errorf("Expected error parsing %q", query)
+			}
+		})
+	}
+}
+
+func TestMapNamedArgs(t *testing.T) {
+	args := []driver.NamedValue{
+		{Name: "b", Ordinal: 1, Value: "b"},
+		{Name: "@A", Ordinal: 2, Value: "a"},
+	}
+
+	mapped, err := mapNamedArgs([]string{"a", "b", "a"}, args)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []driver.Value{"a", "b", "a"}
+	for i, arg := range mapped {
+		if arg.Ordinal != i+1 {
+			t.Errorf("Expected ordinal %d, received %d", i+1, arg.Ordinal)
+		}
+		if arg.Value != expected[i] {
+			t.Errorf("Expected value %v for placeholder %d, received %v", expected[i], i, arg.Value)
+		}
+	}
+
+	if _, err := mapNamedArgs([]string{"a", "c"}, args); err == nil {
+		t.Errorf("Expected error for missing argument")
+	}
+
+	if _, err := mapNamedArgs([]string{"a"}, []driver.NamedValue{{Ordinal: 1, Value: 1}}); err == nil {
+		t.Errorf("Expected error for positional argument")
+	}
+
+	// Named arguments for positional placeholders are bound by their
+	// ordinals.
+	if mapped, err := mapNamedArgs(nil, args); err != nil || !reflect.DeepEqual(mapped, args) {
+		t.Errorf("Expected arguments as-is, received %v (%v)", mapped, err)
+	}
+
+	if index, err := paramIndex([]string{"a", "b"}, &args[0]); err != nil || index != 1 {
+		t.Errorf("Expected index 1, received %d (%v)", index, err)
+	}
+}
@@ -0,0 +1,223 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sync"
+)
+
+// TypeConverter converts between the bytes Client-Library reads from
+// and writes to its buffers and Go values.
+//
+// The bytes are the representation of the base datatype, e.g. for a
+// user-defined datatype based on int these are the four bytes of a
+// CS_INT in host byte order. Character and binary values are passed
+// with their actual length.
+type TypeConverter interface {
+	// GoValue converts the bytes of a fetched column into a Go
+	// value.
+	GoValue(bs []byte) (driver.Value, error)
+	// Bytes converts the value passed for a parameter into the bytes
+	// passed to Client-Library.
+	Bytes(value interface{}) ([]byte, error)
+	// ScanType returns the Go type returned by GoValue.
+	ScanType() reflect.Type
+}
+
+// minUserUsertype is the lowest usertype of user-defined datatypes in
+// systypes.
+const minUserUsertype = 100
+
+var (
+	typeRegistry     = map[ASEType]TypeConverter{}
+	userTypeRegistry = map[string]TypeConverter{}
+	registryLock     sync.RWMutex
+)
+
+// RegisterType registers a converter for columns and parameters of the
+// passed ASEType, replacing the conversion of the driver.
+func RegisterType(t ASEType, conv TypeConverter) {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+
+	if conv == nil {
+		delete(typeRegistry, t)
+		return
+	}
+	typeRegistry[t] = conv
+}
+
+// RegisterUserType registers a converter for columns and parameters of
+// the user-defined datatype with the passed name.
+//
+// User-defined datatypes are looked up in systypes when a connection is
+// opened. Connections opened before the registration or switching the
+// database must call RefreshUserTypes.
+func RegisterUserType(name string, conv TypeConverter) {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+
+	if conv == nil {
+		delete(userTypeRegistry, name)
+		return
+	}
+	userTypeRegistry[name] = conv
+}
+
+// registeredTypeConverter returns the converter registered for the
+// passed ASEType or nil.
+func registeredTypeConverter(t ASEType) TypeConverter {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+
+	return typeRegistry[t]
+}
+
+// typeConverter returns the converter for a column or parameter of the
+// passed ASEType and usertype or nil if the driver converts the
+// values.
+func (conn *Connection) typeConverter(t ASEType, usertype int) TypeConverter {
+	if conn != nil && usertype >= minUserUsertype {
+		conn.userTypesLock.RLock()
+		conv := conn.userTypes[usertype]
+		conn.userTypesLock.RUnlock()
+
+		if conv != nil {
+			return conv
+		}
+	}
+
+	return registeredTypeConverter(t)
+}
+
+// registeredConverters returns the converters registered for types
+// and user-defined datatypes.
+func registeredConverters() []TypeConverter {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+
+	convs := make([]TypeConverter, 0, len(typeRegistry)+len(userTypeRegistry))
+	for _, conv := range typeRegistry {
+		convs = append(convs, conv)
+	}
+	for _, conv := range userTypeRegistry {
+		convs = append(convs, conv)
+	}
+
+	return convs
+}
+
+// hasConverterFor returns true if a converter registered for a type or
+// a user-defined datatype returns values of the type of value. These
+// values are passed to the converter as-is.
+func (conn *Connection) hasConverterFor(value interface{}) bool {
+	t := reflect.TypeOf(value)
+	if t == nil {
+		return false
+	}
+
+	convs := registeredConverters()
+
+	conn.userTypesLock.RLock()
+	for _, conv := range conn.userTypes {
+		convs = append(convs, conv)
+	}
+	conn.userTypesLock.RUnlock()
+
+	for _, conv := range convs {
+		if conv.ScanType() == t {
+			return true
+		}
+	}
+
+	return false
+}
+
+// registeredTypeFor returns the ASEType and the converter registered
+// for it if the converter returns values of the type of value.
+func registeredTypeFor(value interface{}) (ASEType, TypeConverter) {
+	t := reflect.TypeOf(value)
+	if t == nil {
+		return 0, nil
+	}
+
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+
+	for aseType, conv := range typeRegistry {
+		if conv.ScanType() == t {
+			return aseType, conv
+		}
+	}
+
+	return 0, nil
+}
+
+// RefreshUserTypes looks up the usertypes of the registered
+// user-defined datatypes in systypes of the current database.
+func (conn *Connection) RefreshUserTypes(ctx context.Context) error {
+	registryLock.RLock()
+	names := make(map[string]TypeConverter, len(userTypeRegistry))
+	for name, conv := range userTypeRegistry {
+		names[name] = conv
+	}
+	registryLock.RUnlock()
+
+	userTypes := map[int]TypeConverter{}
+
+	if len(names) > 0 {
+		driverRows, _, err := conn.GenericExec(ctx,
+			fmt.Sprintf("select name, usertype from systypes where usertype >= %d", minUserUsertype), nil)
+		if err != nil {
+			return fmt.Errorf("Error reading systypes: %w", err)
+		}
+
+		rows, ok := asRows(driverRows)
+		if !ok {
+			return errors.New("Reading systypes returned no result set")
+		}
+		defer rows.Close()
+
+		values := make([]driver.Value, 2)
+		for {
+			if err := rows.Next(values); err != nil {
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				return fmt.Errorf("Error reading systypes: %w", err)
+			}
+
+			name, ok := values[0].(string)
+			if !ok {
+				return fmt.Errorf("Unexpected type %T for systypes.name", values[0])
+			}
+
+			conv, ok := names[name]
+			if !ok {
+				continue
+			}
+
+			usertype := reflect.ValueOf(values[1])
+			switch usertype.Kind() {
+			case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+				userTypes[int(usertype.Int())] = conv
+			default:
+				return fmt.Errorf("Unexpected type %T for systypes.usertype", values[1])
+			}
+		}
+	}
+
+	conn.userTypesLock.Lock()
+	conn.userTypes = userTypes
+	conn.userTypesLock.Unlock()
+
+	return nil
+}
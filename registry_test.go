@@ -0,0 +1,117 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"testing"
+)
+
+type testConverter struct{ name string }
+
+func (conv testConverter) GoValue(bs []byte) (driver.Value, error) { return conv.name, nil }
+func (conv testConverter) Bytes(value interface{}) ([]byte, error) { return nil, nil }
+func (conv testConverter) ScanType() reflect.Type                  { return reflect.TypeOf("") }
+
+func TestTypeConverter(t *testing.T) {
+	typeConv := testConverter{"type"}
+	userConv := testConverter{"user"}
+
+	RegisterType(USER, typeConv)
+	defer RegisterType(USER, nil)
+
+	conn := &Connection{userTypes: map[int]TypeConverter{100: userConv}}
+
+	cases := map[string]struct {
+		conn     *Connection
+		t        ASEType
+		usertype int
+		expected TypeConverter
+	}{
+		"registered type":      {conn, USER, 0, typeConv},
+		"unregistered type":    {conn, INT, 7, nil},
+		"user type":            {conn, INT, 100, userConv},
+		"user type precedence": {conn, USER, 100, userConv},
+		"unknown user type":    {conn, USER, 101, typeConv},
+		"without connection":   {nil, USER, 100, typeConv},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			if conv := tc.conn.typeConverter(tc.t, tc.usertype); conv != tc.expected {
+				t.Errorf("Expected converter %v, received %v", tc.expected, conv)
+			}
+		})
+	}
+}
+
+type customValue struct{ value string }
+
+type customConverter struct{}
+
+func (customConverter) GoValue(bs []byte) (driver.Value, error) { return customValue{string(bs)}, nil }
+func (customConverter) Bytes(value interface{}) ([]byte, error) {
+	return []byte(value.(customValue).value), nil
+}
+func (customConverter) ScanType() reflect.Type { return reflect.TypeOf(customValue{}) }
+
+func TestConnectionCheckNamedValueConverter(t *testing.T) {
+	value := customValue{"abc"}
+
+	cases := map[string]struct {
+		register func(conn *Connection) func()
+		err      bool
+	}{
+		"unregistered": {
+			register: func(conn *Connection) func() { return func() {} },
+			err:      true,
+		},
+		"registered type": {
+			register: func(conn *Connection) func() {
+				RegisterType(USER, customConverter{})
+				return func() { RegisterType(USER, nil) }
+			},
+		},
+		"registered user type": {
+			register: func(conn *Connection) func() {
+				RegisterUserType("custom", customConverter{})
+				return func() { RegisterUserType("custom", nil) }
+			},
+		},
+		"user type of connection": {
+			register: func(conn *Connection) func() {
+				conn.userTypes = map[int]TypeConverter{100: customConverter{}}
+				return func() {}
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			conn := &Connection{}
+			defer tc.register(conn)()
+
+			nv := &driver.NamedValue{Ordinal: 1, Value: value}
+			err := conn.CheckNamedValue(nv)
+			if tc.err {
+				if err == nil {
+					t.Errorf("Expected error for unregistered type")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if nv.Value != value {
+				t.Errorf("Expected value to be passed as-is, received %#v", nv.Value)
+			}
+		})
+	}
+}
@@ -10,19 +10,98 @@ import (
 )
 
 // Interface satisfaction checks
-var _ driver.Result = Result{}
+var (
+	_ driver.Result = Result{}
+	_ BatchResult   = Result{}
+)
 
 // Result implements the driver.Result interface.
 type Result struct {
 	rowsAffected int64
+	// statements contains the results of all statements of the
+	// command in order.
+	statements []StatementResult
+	// lastInsertID is the identity value of the last insert, set if
+	// hasLastInsertID is set.
+	lastInsertID    int64
+	hasLastInsertID bool
+	// returnStatus is the return status of the last stored procedure
+	// executed, set if hasReturnStatus is set.
+	returnStatus    int32
+	hasReturnStatus bool
+}
+
+// asResult returns the *Result of result returned by GenericExec. The
+// second return value is false if no result was returned, which
+// GenericExec signals with a typed nil pointer.
+func asResult(result driver.Result) (*Result, bool) {
+	res, ok := result.(*Result)
+	return res, ok && res != nil
+}
+
+// StatementResult is the result of a single statement of a command as
+// reported at its end.
+type StatementResult struct {
+	// RowsAffected is the number of rows affected by the statement, -1
+	// if the statement does not affect rows.
+	RowsAffected int64
+	// TransactionState is the state of the transaction after the
+	// statement.
+	TransactionState TransactionState
+	// Failed is set if the statement failed, RowsAffected is -1 then.
+	Failed bool
+}
+
+// appendResult appends the statements of next to result and returns
+// result with the rows affected by the last statement of next.
+func appendResult(result, next *Result) *Result {
+	switch {
+	case next == nil:
+		return result
+	case result == nil:
+		return next
+	}
+
+	result.rowsAffected = next.rowsAffected
+	result.statements = append(result.statements, next.statements...)
+	return result
+}
+
+// BatchResult is implemented by the driver.Result returned for commands
+// and gives access to the results of each statement of a batch.
+//
+// RowsAffected returns the number of rows affected by the last
+// statement.
+type BatchResult interface {
+	driver.Result
+	Statements() []StatementResult
 }
 
 // LastInsertId implements the driver.Result interface.
+//
+// The identity value of the last insert is only returned if the
+// last-insert-id property is set.
 func (result Result) LastInsertId() (int64, error) {
-	return -1, errors.New("Feature not supported")
+	if !result.hasLastInsertID {
+		return -1, errors.New("Feature not supported, set last-insert-id to enable")
+	}
+
+	return result.lastInsertID, nil
 }
 
 // RowsAffected implements the driver.Result interface.
 func (result Result) RowsAffected() (int64, error) {
 	return result.rowsAffected, nil
 }
+
+// Statements implements the BatchResult interface.
+func (result Result) Statements() []StatementResult {
+	return result.statements
+}
+
+// ReturnStatus returns the return status of the last stored procedure
+// executed by the command. The second return value is false if no
+// stored procedure was executed.
+func (result Result) ReturnStatus() (int32, bool) {
+	return result.returnStatus, result.hasReturnStatus
+}
@@ -0,0 +1,162 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"testing"
+)
+
+func TestBatchResult(t *testing.T) {
+	statements := []StatementResult{
+		{RowsAffected: 3, TransactionState: TransactionInProgress},
+		{RowsAffected: -1, TransactionState: TransactionInProgress},
+		{RowsAffected: 1, TransactionState: TransactionCompleted},
+	}
+
+	var result driver.Result = &Result{rowsAffected: 1, statements: statements}
+
+	batch, ok := result.(BatchResult)
+	if !ok {
+		t.Fatalf("Expected result to implement BatchResult")
+	}
+
+	if !reflect.DeepEqual(batch.Statements(), statements) {
+		t.Errorf("Expected statements %v, got %v", statements, batch.Statements())
+	}
+
+	affected, err := batch.RowsAffected()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if affected != 1 {
+		t.Errorf("Expected 1 affected row, got %d", affected)
+	}
+}
+
+func TestAsResult(t *testing.T) {
+	var typedNil *Result
+	result := &Result{rowsAffected: 1}
+
+	cases := map[string]struct {
+		result driver.Result
+		ok     bool
+	}{
+		"nil":       {nil, false},
+		"typed nil": {typedNil, false},
+		"value":     {Result{}, false},
+		"result":    {result, true},
+	}
+
+	for title, cas := range cases {
+		t.Run(title, func(t *testing.T) {
+			res, ok := asResult(cas.result)
+			if ok != cas.ok {
+				t.Fatalf("Expected %t, received %t", cas.ok, ok)
+			}
+			if ok && res != result {
+				t.Errorf("Expected %p, received %p", result, res)
+			}
+		})
+	}
+}
+
+func TestLastInsertId(t *testing.T) {
+	if _, err := (Result{}).LastInsertId(); err == nil {
+		t.Errorf("Expected error without last insert id")
+	}
+
+	id, err := (Result{lastInsertID: 42, hasLastInsertID: true}).LastInsertId()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if id != 42 {
+		t.Errorf("Expected last insert id 42, got %d", id)
+	}
+}
+
+func TestReturnStatus(t *testing.T) {
+	if _, ok := (Result{}).ReturnStatus(); ok {
+		t.Errorf("Expected no return status")
+	}
+
+	status, ok := (Result{returnStatus: -6, hasReturnStatus: true}).ReturnStatus()
+	if !ok {
+		t.Fatalf("Expected return status")
+	}
+
+	if status != -6 {
+		t.Errorf("Expected return status -6, got %d", status)
+	}
+}
+
+func TestAppendResult(t *testing.T) {
+	first := StatementResult{RowsAffected: 3, TransactionState: TransactionInProgress}
+	second := StatementResult{RowsAffected: 1, TransactionState: TransactionInProgress}
+	failed := StatementResult{RowsAffected: -1, TransactionState: TransactionStmtFailed, Failed: true}
+
+	cases := map[string]struct {
+		results  []*Result
+		expected *Result
+	}{
+		"none": {
+			results:  []*Result{nil, nil},
+			expected: nil,
+		},
+		"single": {
+			results:  []*Result{nil, {rowsAffected: 3, statements: []StatementResult{first}}, nil},
+			expected: &Result{rowsAffected: 3, statements: []StatementResult{first}},
+		},
+		"multiple": {
+			results: []*Result{
+				{rowsAffected: 3, statements: []StatementResult{first}},
+				nil,
+				{rowsAffected: 1, statements: []StatementResult{second}},
+			},
+			expected: &Result{rowsAffected: 1, statements: []StatementResult{first, second}},
+		},
+		"failed": {
+			results: []*Result{
+				{rowsAffected: 3, statements: []StatementResult{first}},
+				{rowsAffected: -1, statements: []StatementResult{failed}},
+			},
+			expected: &Result{rowsAffected: -1, statements: []StatementResult{first, failed}},
+		},
+	}
+
+	for title, cas := range cases {
+		t.Run(title, func(t *testing.T) {
+			var result *Result
+			for _, next := range cas.results {
+				result = appendResult(result, next)
+			}
+
+			if !reflect.DeepEqual(result, cas.expected) {
+				t.Errorf("Expected %#v, received %#v", cas.expected, result)
+			}
+		})
+	}
+}
+
+func TestCommandResetResults(t *testing.T) {
+	cmd := &Command{returnStatus: -6, hasReturnStatus: true}
+
+	if _, ok := cmd.withReturnStatus(&Result{}).ReturnStatus(); !ok {
+		t.Fatalf("Expected return status before reset")
+	}
+
+	cmd.resetResults()
+
+	if cmd.returnStatus != 0 || cmd.hasReturnStatus {
+		t.Errorf("Expected return status to be reset, received %d and %t", cmd.returnStatus, cmd.hasReturnStatus)
+	}
+
+	if _, ok := cmd.withReturnStatus(&Result{}).ReturnStatus(); ok {
+		t.Errorf("Expected no return status after reset")
+	}
+}
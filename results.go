@@ -0,0 +1,339 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+//#include <stdlib.h>
+//#include "ctlib.h"
+import "C"
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"unsafe"
+)
+
+// ResultKind is the kind of a result reported by ct_results.
+type ResultKind int
+
+// Kinds of results returned by Command.NextResult.
+const (
+	// ResultRows is a regular result set.
+	ResultRows ResultKind = iota + 1
+	// ResultCompute is the result set of a compute clause.
+	ResultCompute
+	// ResultCursor is the result set of a cursor.
+	ResultCursor
+	// ResultParams contains the values of output parameters.
+	ResultParams
+	// ResultStatus contains the return status of a stored procedure.
+	ResultStatus
+	// ResultMessage signals a message from the server with a message
+	// id.
+	ResultMessage
+	// ResultRowFormat signals the format of the following row results.
+	ResultRowFormat
+	// ResultComputeFormat signals the format of the following compute
+	// results.
+	ResultComputeFormat
+	// ResultDescribe contains the description of a dynamic statement.
+	ResultDescribe
+	// ResultDone signals the end of a statement.
+	ResultDone
+	// ResultSucceed signals the successful end of a statement without
+	// rows.
+	ResultSucceed
+	// ResultFail signals a failed statement.
+	ResultFail
+)
+
+func (kind ResultKind) String() string {
+	switch kind {
+	case ResultRows:
+		return "rows"
+	case ResultCompute:
+		return "compute"
+	case ResultCursor:
+		return "cursor"
+	case ResultParams:
+		return "params"
+	case ResultStatus:
+		return "status"
+	case ResultMessage:
+		return "message"
+	case ResultRowFormat:
+		return "row format"
+	case ResultComputeFormat:
+		return "compute format"
+	case ResultDescribe:
+		return "describe"
+	case ResultDone:
+		return "done"
+	case ResultSucceed:
+		return "succeed"
+	case ResultFail:
+		return "fail"
+	default:
+		return fmt.Sprintf("ResultKind(%d)", int(kind))
+	}
+}
+
+// TransactionState is the state of the current transaction as reported
+// with the end of a statement.
+type TransactionState int
+
+// Transaction states of CS_TRANS_STATE.
+const (
+	TransactionUndefined  TransactionState = C.CS_TRAN_UNDEFINED
+	TransactionInProgress TransactionState = C.CS_TRAN_IN_PROGRESS
+	TransactionCompleted  TransactionState = C.CS_TRAN_COMPLETED
+	TransactionFailed     TransactionState = C.CS_TRAN_FAIL
+	TransactionStmtFailed TransactionState = C.CS_TRAN_STMT_FAIL
+)
+
+func (state TransactionState) String() string {
+	switch state {
+	case TransactionUndefined:
+		return "undefined"
+	case TransactionInProgress:
+		return "in progress"
+	case TransactionCompleted:
+		return "completed"
+	case TransactionFailed:
+		return "failed"
+	case TransactionStmtFailed:
+		return "statement failed"
+	default:
+		return fmt.Sprintf("TransactionState(%d)", int(state))
+	}
+}
+
+// ResultEvent is a single result of a command. Which fields are set
+// depends on the Kind.
+type ResultEvent struct {
+	Kind ResultKind
+
+	// Rows is set for ResultRows, ResultCompute and ResultCursor. The
+	// rows are valid until the next call to NextResult, closing them
+	// discards the remaining rows of the result. Rows.ComputeInfo
+	// describes the rows of ResultCompute.
+	Rows *Rows
+	// ReturnStatus is set for ResultStatus.
+	ReturnStatus int32
+	// Params contains the output parameters for ResultParams.
+	Params []driver.NamedValue
+	// MessageID is set for ResultMessage.
+	MessageID int
+	// RowCount is set for ResultDone, -1 if the statement did not
+	// affect rows.
+	RowCount int64
+	// TransactionState is set for ResultDone and ResultFail.
+	TransactionState TransactionState
+	// Err is set for ResultFail.
+	Err error
+}
+
+// NextResult reads the next result of the command and returns it as
+// an event. Unlike Response every result reported by ct_results is
+// returned, allowing to inspect exactly what a batch returned.
+//
+// Return status and output parameters are fetched and returned as
+// values. Rows returned with an event are discarded if they were not
+// read when NextResult is called again.
+//
+// When no more results are available io.EOF is returned.
+func (cmd *Command) NextResult() (*ResultEvent, error) {
+	if cmd.pending != nil {
+		err := cmd.pending.Close()
+		cmd.pending = nil
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var resultType C.CS_INT
+	retval := C.ct_results(cmd.cmd, &resultType)
+
+	switch retval {
+	case C.CS_SUCCEED:
+		break
+	case C.CS_END_RESULTS:
+		return nil, io.EOF
+	case C.CS_FAIL:
+		cmd.Cancel()
+		return nil, cmd.conn.commandError(makeError(retval, "Command failed"))
+	default:
+		cmd.Cancel()
+		return nil, makeError(retval, "Invalid return code")
+	}
+
+	switch resultType {
+	case C.CS_ROW_RESULT:
+		return cmd.rowsResult(ResultRows)
+	case C.CS_COMPUTE_RESULT:
+		return cmd.rowsResult(ResultCompute)
+	case C.CS_CURSOR_RESULT:
+		return cmd.rowsResult(ResultCursor)
+	case C.CS_STATUS_RESULT:
+		status, err := cmd.fetchReturnStatus()
+		if err != nil {
+			return nil, err
+		}
+		return &ResultEvent{Kind: ResultStatus, ReturnStatus: status}, nil
+	case C.CS_PARAM_RESULT:
+		values, names, err := cmd.fetchValues()
+		if err != nil {
+			return nil, err
+		}
+
+		params := make([]driver.NamedValue, len(values))
+		for i := range values {
+			params[i] = driver.NamedValue{Name: names[i], Ordinal: i + 1, Value: values[i]}
+		}
+		return &ResultEvent{Kind: ResultParams, Params: params}, nil
+	case C.CS_MSG_RESULT:
+		var msgID C.CS_USHORT
+		retval := C.ct_res_info(cmd.cmd, C.CS_MSGTYPE, unsafe.Pointer(&msgID), C.CS_UNUSED, nil)
+		if retval != C.CS_SUCCEED {
+			cmd.Cancel()
+			return nil, makeError(retval, "Failed to read message id")
+		}
+		return &ResultEvent{Kind: ResultMessage, MessageID: int(msgID)}, nil
+	case C.CS_ROWFMT_RESULT:
+		return &ResultEvent{Kind: ResultRowFormat}, nil
+	case C.CS_COMPUTEFMT_RESULT:
+		return &ResultEvent{Kind: ResultComputeFormat}, nil
+	case C.CS_DESCRIBE_RESULT:
+		return &ResultEvent{Kind: ResultDescribe}, nil
+	case C.CS_CMD_DONE:
+		var rowCount C.CS_INT
+		retval := C.ct_res_info(cmd.cmd, C.CS_ROW_COUNT, unsafe.Pointer(&rowCount), C.CS_UNUSED, nil)
+		if retval != C.CS_SUCCEED {
+			cmd.Cancel()
+			return nil, makeError(retval, "Failed to read affected rows")
+		}
+
+		return &ResultEvent{Kind: ResultDone, RowCount: int64(rowCount), TransactionState: cmd.transactionState()}, nil
+	case C.CS_CMD_SUCCEED:
+		return &ResultEvent{Kind: ResultSucceed}, nil
+	case C.CS_CMD_FAIL:
+		return &ResultEvent{
+			Kind:             ResultFail,
+			TransactionState: cmd.transactionState(),
+			Err:              cmd.conn.commandError(errors.New("Command failed")),
+		}, nil
+	default:
+		cmd.Cancel()
+		return nil, fmt.Errorf("Unknown result type: %d", resultType)
+	}
+}
+
+// rowsResult binds the columns of a fetchable result and returns them
+// as an event of the passed kind.
+func (cmd *Command) rowsResult(kind ResultKind) (*ResultEvent, error) {
+	bind := newRows
+	if kind == ResultCompute {
+		bind = newComputeRows
+	}
+
+	rows, err := bind(cmd)
+	if err != nil {
+		cmd.Cancel()
+		return nil, err
+	}
+
+	rows.inResults = true
+	cmd.pending = rows
+	return &ResultEvent{Kind: kind, Rows: rows}, nil
+}
+
+// fetchValues fetches the single row of a parameter result
+// and returns its values and column names.
+func (cmd *Command) fetchValues() ([]driver.Value, []string, error) {
+	rows, err := newRows(cmd)
+	if err != nil {
+		cmd.Cancel()
+		return nil, nil, err
+	}
+	rows.inResults = true
+	defer rows.Close()
+
+	values := make([]driver.Value, rows.numCols)
+	if err := rows.Next(values); err != nil {
+		return nil, nil, fmt.Errorf("Error fetching result values: %w", err)
+	}
+
+	// Read to the end of the result so that it is not cancelled.
+	err = rows.Next(make([]driver.Value, rows.numCols))
+	switch {
+	case err == nil:
+		return nil, nil, errors.New("Received more than one row of result values")
+	case !errors.Is(err, io.EOF):
+		return nil, nil, fmt.Errorf("Error fetching result values: %w", err)
+	}
+
+	return values, rows.Columns(), nil
+}
+
+// fetchReturnStatus fetches the return status of a status result.
+//
+// The status is bound as CS_INT directly instead of being read as rows,
+// which would apply registered converters and fallback types.
+func (cmd *Command) fetchReturnStatus() (int32, error) {
+	datafmt := (*C.CS_DATAFMT)(C.calloc(1, C.sizeof_CS_DATAFMT))
+	defer C.free(unsafe.Pointer(datafmt))
+	datafmt.datatype = C.CS_INT_TYPE
+	datafmt.maxlength = C.sizeof_CS_INT
+	datafmt.format = C.CS_FMT_UNUSED
+	datafmt.count = 1
+
+	status := (*C.CS_INT)(C.calloc(1, C.sizeof_CS_INT))
+	defer C.free(unsafe.Pointer(status))
+
+	retval := C.ct_bind(cmd.cmd, 1, datafmt, unsafe.Pointer(status), nil, nil)
+	if retval != C.CS_SUCCEED {
+		cmd.Cancel()
+		return 0, makeError(retval, "Failed to bind return status")
+	}
+
+	// Read to the end of the result so that it is not cancelled.
+	found := false
+	for {
+		retval = C.ct_fetch(cmd.cmd, C.CS_UNUSED, C.CS_UNUSED, C.CS_UNUSED, nil)
+		if retval == C.CS_END_DATA {
+			break
+		}
+		if retval != C.CS_SUCCEED {
+			cmd.Cancel()
+			return 0, makeError(retval, "Failed to fetch return status")
+		}
+
+		if found {
+			cmd.Cancel()
+			return 0, errors.New("Received more than one return status")
+		}
+		found = true
+	}
+
+	if !found {
+		return 0, errors.New("Received status result without return status")
+	}
+
+	return int32(*status), nil
+}
+
+// transactionState returns the state of the current transaction.
+//
+// The state is informational, TransactionUndefined is returned if it
+// cannot be read rather than failing the result it belongs to.
+func (cmd *Command) transactionState() TransactionState {
+	var state C.CS_INT
+	retval := C.ct_res_info(cmd.cmd, C.CS_TRANS_STATE, unsafe.Pointer(&state), C.CS_UNUSED, nil)
+	if retval != C.CS_SUCCEED {
+		return TransactionUndefined
+	}
+
+	return TransactionState(state)
+}
@@ -0,0 +1,328 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// +build integration
+
+package ase
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"testing"
+)
+
+// withConnection calls fn with a Connection to the server configured
+// in the environment and modified by infoMod if it is not nil.
+func withConnection(t *testing.T, infoMod func(*Info), fn func(conn *Connection)) {
+	info, err := NewInfoWithEnv()
+	if err != nil {
+		t.Fatalf("Error reading info from environment: %v", err)
+	}
+
+	if infoMod != nil {
+		infoMod(info)
+	}
+
+	connector, err := NewConnector(info)
+	if err != nil {
+		t.Fatalf("Error creating connector: %v", err)
+	}
+
+	db := sql.OpenDB(connector)
+	defer db.Close()
+
+	sqlConn, err := db.Conn(context.Background())
+	if err != nil {
+		t.Fatalf("Error opening connection: %v", err)
+	}
+	defer sqlConn.Close()
+
+	if err := sqlConn.Raw(func(driverConn interface{}) error {
+		fn(driverConn.(*Connection))
+		return nil
+	}); err != nil {
+		t.Fatalf("Error accessing driver connection: %v", err)
+	}
+}
+
+// resultKinds returns the kinds of all results of the command. Rows are
+// read to the end if readRows is true and left for NextResult to
+// discard otherwise.
+func resultKinds(t *testing.T, cmd *Command, readRows bool) []ResultKind {
+	kinds := []ResultKind{}
+	for {
+		event, err := cmd.NextResult()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return kinds
+			}
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		kinds = append(kinds, event.Kind)
+
+		if event.Rows == nil || !readRows {
+			continue
+		}
+
+		dest := make([]driver.Value, len(event.Rows.Columns()))
+		for {
+			if err := event.Rows.Next(dest); err != nil {
+				if err != io.EOF {
+					t.Fatalf("Unexpected error reading rows: %v", err)
+				}
+				break
+			}
+		}
+	}
+}
+
+func TestNextResult(t *testing.T) {
+	query := "select 1 union select 2 select 3 select 4"
+	expected := []ResultKind{
+		ResultRows, ResultDone,
+		ResultRows, ResultDone,
+		ResultRows, ResultDone,
+	}
+
+	for _, readRows := range []bool{true, false} {
+		withConnection(t, nil, func(conn *Connection) {
+			cmd, err := conn.NewCommand(context.Background(), query)
+			if err != nil {
+				t.Fatalf("Error sending command: %v", err)
+			}
+			defer cmd.Drop()
+
+			kinds := resultKinds(t, cmd, readRows)
+			if len(kinds) != len(expected) {
+				t.Fatalf("Read rows %t: expected results %v, received %v", readRows, expected, kinds)
+			}
+
+			for i := range kinds {
+				if kinds[i] != expected[i] {
+					t.Errorf("Read rows %t: expected results %v, received %v", readRows, expected, kinds)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestNextResultFail(t *testing.T) {
+	withConnection(t, nil, func(conn *Connection) {
+		cmd, err := conn.NewCommand(context.Background(), "select 1 select convert(int, 'x') select 2")
+		if err != nil {
+			t.Fatalf("Error sending command: %v", err)
+		}
+		defer cmd.Drop()
+
+		var fail *ResultEvent
+		for {
+			event, err := cmd.NextResult()
+			if err != nil {
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if event.Kind == ResultFail {
+				fail = event
+			}
+		}
+
+		if fail == nil {
+			t.Fatalf("Expected a failed statement")
+		}
+
+		if fail.Err == nil {
+			t.Errorf("Expected error for failed statement")
+		}
+	})
+}
+
+func TestNextResultCompute(t *testing.T) {
+	query := "select id, type from sysobjects where id < 100 order by type compute count(id), max(id) by type"
+
+	withConnection(t, nil, func(conn *Connection) {
+		cmd, err := conn.NewCommand(context.Background(), query)
+		if err != nil {
+			t.Fatalf("Error sending command: %v", err)
+		}
+		defer cmd.Drop()
+
+		computes := 0
+		for {
+			event, err := cmd.NextResult()
+			if err != nil {
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if event.Kind != ResultCompute {
+				if event.Rows != nil && event.Rows.ComputeInfo() != nil {
+					t.Errorf("Expected no compute info for %s rows", event.Kind)
+				}
+				continue
+			}
+			computes++
+
+			info := event.Rows.ComputeInfo()
+			if info == nil {
+				t.Fatalf("Expected compute info for compute rows")
+			}
+
+			expected := []ComputeColumn{{ComputeCount, 1}, {ComputeMax, 1}}
+			if len(info.Columns) != len(expected) {
+				t.Fatalf("Expected compute columns %v, received %v", expected, info.Columns)
+			}
+			for i := range expected {
+				if info.Columns[i] != expected[i] {
+					t.Errorf("Expected compute columns %v, received %v", expected, info.Columns)
+				}
+			}
+
+			if len(info.ByList) != 1 || info.ByList[0] != 2 {
+				t.Errorf("Expected by list [2], received %v", info.ByList)
+			}
+		}
+
+		if computes == 0 {
+			t.Errorf("Expected compute rows")
+		}
+	})
+}
+
+func TestExecContextStatements(t *testing.T) {
+	withConnection(t, nil, func(conn *Connection) {
+		ctx := context.Background()
+		if _, err := conn.ExecContext(ctx, "create table #statements (a int)", nil); err != nil {
+			t.Fatalf("Error creating table: %v", err)
+		}
+
+		result, err := conn.ExecContext(ctx,
+			"insert #statements values (1) insert #statements values (2) select * from #statements update #statements set a = 3", nil)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		statements := result.(BatchResult).Statements()
+		if len(statements) < 3 {
+			t.Fatalf("Expected the results of all statements, received %v", statements)
+		}
+
+		for i, expected := range []int64{1, 1} {
+			if statements[i].RowsAffected != expected {
+				t.Errorf("Statement %d: expected %d affected rows, received %d", i+1, expected, statements[i].RowsAffected)
+			}
+		}
+
+		if last := statements[len(statements)-1]; last.RowsAffected != 2 {
+			t.Errorf("Expected update after result set to affect 2 rows, received %d", last.RowsAffected)
+		}
+
+		if affected, _ := result.RowsAffected(); affected != 2 {
+			t.Errorf("Expected 2 affected rows, received %d", affected)
+		}
+	})
+}
+
+func TestGenericExecFailedStatement(t *testing.T) {
+	withConnection(t, nil, func(conn *Connection) {
+		ctx := context.Background()
+		if _, err := conn.ExecContext(ctx, "create table #failed (a int)", nil); err != nil {
+			t.Fatalf("Error creating table: %v", err)
+		}
+
+		_, result, err := conn.GenericExec(ctx,
+			"insert #failed values (1) insert #failed select convert(int, 'x') insert #failed values (2)", nil)
+		if err == nil {
+			t.Fatalf("Expected error for failed statement")
+		}
+
+		res, ok := asResult(result)
+		if !ok {
+			t.Fatalf("Expected result alongside the error")
+		}
+
+		statements := res.Statements()
+		if len(statements) != 2 {
+			t.Fatalf("Expected the results of two statements, received %v", statements)
+		}
+
+		if statements[0].Failed || statements[0].RowsAffected != 1 {
+			t.Errorf("Expected first statement to affect 1 row, received %+v", statements[0])
+		}
+
+		if !statements[1].Failed || statements[1].RowsAffected != -1 {
+			t.Errorf("Expected second statement to be failed, received %+v", statements[1])
+		}
+	})
+}
+
+func TestLastInsertID(t *testing.T) {
+	withConnection(t, func(info *Info) { info.LastInsertID = true }, func(conn *Connection) {
+		ctx := context.Background()
+		if _, err := conn.ExecContext(ctx, "create table #identity (id numeric(38, 0) identity, a int)", nil); err != nil {
+			t.Fatalf("Error creating table: %v", err)
+		}
+
+		for _, expected := range []int64{1, 2} {
+			result, err := conn.ExecContext(ctx, "insert #identity (a) values (1)", nil)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			id, err := result.LastInsertId()
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if id != expected {
+				t.Errorf("Expected last insert id %d, received %d", expected, id)
+			}
+		}
+
+		// Statements without inserts do not read @@identity.
+		result, err := conn.ExecContext(ctx, "update #identity set a = 2", nil)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if id, err := result.LastInsertId(); err != nil || id != 0 {
+			t.Errorf("Expected last insert id 0, received %d and %v", id, err)
+		}
+	})
+}
+
+func TestReturnStatusConverter(t *testing.T) {
+	// The return status is read without the converters of INT columns.
+	RegisterType(INT, customConverter{})
+	defer RegisterType(INT, nil)
+
+	withConnection(t, nil, func(conn *Connection) {
+		ctx := context.Background()
+		if _, err := conn.ExecContext(ctx, "create procedure #status as return 3", nil); err != nil {
+			t.Fatalf("Error creating procedure: %v", err)
+		}
+
+		result, err := conn.ExecContext(ctx, "exec #status", nil)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		status, ok := result.(*Result).ReturnStatus()
+		if !ok {
+			t.Fatalf("Expected return status")
+		}
+
+		if status != 3 {
+			t.Errorf("Expected return status 3, received %d", status)
+		}
+	})
+}
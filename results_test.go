@@ -0,0 +1,44 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import "testing"
+
+func TestResultKindString(t *testing.T) {
+	cases := map[ResultKind]string{
+		ResultRows:          "rows",
+		ResultCompute:       "compute",
+		ResultParams:        "params",
+		ResultStatus:        "status",
+		ResultMessage:       "message",
+		ResultComputeFormat: "compute format",
+		ResultDone:          "done",
+		ResultFail:          "fail",
+		ResultKind(0):       "ResultKind(0)",
+	}
+
+	for kind, expected := range cases {
+		if s := kind.String(); s != expected {
+			t.Errorf("Expected %q, got %q", expected, s)
+		}
+	}
+}
+
+func TestTransactionStateString(t *testing.T) {
+	cases := map[TransactionState]string{
+		TransactionUndefined:  "undefined",
+		TransactionInProgress: "in progress",
+		TransactionCompleted:  "completed",
+		TransactionFailed:     "failed",
+		TransactionStmtFailed: "statement failed",
+		TransactionState(42):  "TransactionState(42)",
+	}
+
+	for state, expected := range cases {
+		if s := state.String(); s != expected {
+			t.Errorf("Expected %q, got %q", expected, s)
+		}
+	}
+}
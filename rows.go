@@ -11,9 +11,11 @@ import "C"
 import (
 	"database/sql/driver"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"io"
 	"reflect"
+	"strings"
 	"unsafe"
 
 	"github.com/SAP/go-dblib/asetypes"
@@ -39,13 +41,86 @@ type Rows struct {
 	// contains information regarding the column, such as the data type
 	// or size.
 	dataFmts []*C.CS_DATAFMT
+	// colNames contains the names of the columns.
+	colNames []string
 	// colASEType maps directly to columns in the result set.
 	// Each colASEType is the ASEType for the column.
 	colASEType []ASEType
-	// colData is a pointer to allocated memory according to the type
-	// and size as indicated by the dataFmt.
+	// colData is allocated memory according to the type and size as
+	// indicated by the dataFmt.
 	// the ctlibrary copies field data into this memory.
-	colData []unsafe.Pointer
+	colData [][]byte
+	// colCopied is allocated memory the ctlibrary writes the number
+	// of bytes copied into colData into.
+	colCopied []int32
+	// colIndicators is allocated memory the ctlibrary writes the
+	// indicators of the values into, -1 signals null.
+	colIndicators []int16
+	// allocs contains the C memory colData, colCopied and
+	// colIndicators point into.
+	allocs []unsafe.Pointer
+	// fetch fetches the next batch of rows into the bound memory and
+	// returns the number of fetched rows or io.EOF.
+	fetch func() (int, error)
+	// colStrides contains the number of bytes of each value of a
+	// column in colData.
+	colStrides []int
+	// batchSize is the number of rows fetched with each ct_fetch,
+	// colCopied and colIndicators contain batchSize entries per
+	// column.
+	batchSize int
+	// fetched is the number of rows in the current batch and current
+	// the index of the current row in the batch.
+	fetched, current int
+	// trimChar signals that trailing blanks are removed from
+	// character columns.
+	trimChar bool
+	// native converts values into idiomatic Go types, nil if native
+	// types are not enabled.
+	native *nativeConverter
+	// colConverters contains the converters registered for the types
+	// of columns, nil for columns converted by the driver.
+	colConverters []TypeConverter
+	// colFallback contains the type values of columns are converted
+	// into with cs_convert for types the driver does not support, 0
+	// for supported types.
+	colFallback []ASEType
+
+	// colLocators contains the locators bound to columns of locator
+	// types. The locators are handed out with each fetched row and
+	// replaced by newly bound locators.
+	colLocators []*Locator
+
+	// lobColumns marks columns which are not bound and instead are
+	// returned as a *LOBReader, reading the data with ct_get_data.
+	lobColumns []bool
+	// rowNumber is increased with each fetched row and used to
+	// invalidate LOBReaders of previous rows.
+	rowNumber uint64
+	// lobItem is the highest item read through a LOBReader in the
+	// current row. Client-Library only allows to read unbound items in
+	// ascending order.
+	lobItem int
+
+	// computeInfo describes the compute clause for compute results,
+	// nil otherwise.
+	computeInfo *ComputeInfo
+
+	// done is set when all rows of the result set were fetched.
+	done bool
+	// inResults is set for rows returned by Command.NextResult. Closing
+	// them only discards the current result instead of the command.
+	inResults bool
+	// drained is set when the results following the rows were read.
+	drained bool
+}
+
+// asRows returns the *Rows of rows returned by GenericExec. The second
+// return value is false if no result set was returned, which
+// GenericExec signals with a typed nil pointer.
+func asRows(rows driver.Rows) (*Rows, bool) {
+	r, ok := rows.(*Rows)
+	return r, ok && r != nil
 }
 
 // TODO: Add doc
@@ -61,14 +136,29 @@ func newRows(cmd *Command) (*Rows, error) {
 	}
 
 	r := &Rows{
-		cmd:        cmd,
-		numCols:    int(numCols),
-		dataFmts:   make([]*C.CS_DATAFMT, int(numCols)),
-		colASEType: make([]ASEType, int(numCols)),
-		colData:    make([]unsafe.Pointer, int(numCols)),
+		cmd:         cmd,
+		numCols:     int(numCols),
+		dataFmts:    make([]*C.CS_DATAFMT, int(numCols)),
+		colNames:    make([]string, int(numCols)),
+		colASEType:  make([]ASEType, int(numCols)),
+		colData:     make([][]byte, int(numCols)),
+		lobColumns:  make([]bool, int(numCols)),
+		colLocators: make([]*Locator, int(numCols)),
+
+		colConverters: make([]TypeConverter, int(numCols)),
+		colFallback:   make([]ASEType, int(numCols)),
 	}
+	r.fetch = r.ctFetch
 
-	// Setup column and row memory for ct to write into
+	if cmd.conn != nil {
+		r.trimChar = cmd.conn.driverCtx.info.TrimChar
+		r.native = cmd.conn.native
+	}
+
+	streamLOBs := cmd.conn != nil && cmd.conn.driverCtx.info.LOBStreaming
+
+	// Describe all columns before binding them, since the number of
+	// rows fetched at once depends on the types of all columns.
 	for i := 0; i < r.numCols; i++ {
 		// Allocate columns dataFmt
 		r.dataFmts[i] = (*C.CS_DATAFMT)(C.calloc(1, C.sizeof_CS_DATAFMT))
@@ -79,32 +169,99 @@ func newRows(cmd *Command) (*Rows, error) {
 			r.Close()
 			return nil, makeError(retval, "Failed to retrieve description of column")
 		}
+		r.colNames[i] = C.GoString(&r.dataFmts[i].name[0])
 
 		// Set ASEType for column
-		asetype := (ASEType)(r.dataFmts[i].datatype)
+		asetype := (ASEType)(r.dataFmts[i].datatype).withUsertype(int(r.dataFmts[i].usertype))
 		if asetype.String() == "" {
-			r.Close()
-			return nil, fmt.Errorf("Invalid ASEType: %v", r.dataFmts[i].datatype)
+			// Types unknown to the driver are bound as-is and
+			// converted with cs_convert.
+			r.colFallback[i] = cmd.conn.fallbackType(asetype)
+			if r.colFallback[i] == 0 {
+				r.Close()
+				return nil, fmt.Errorf("Invalid ASEType: %v", r.dataFmts[i].datatype)
+			}
 		}
 
 		r.colASEType[i] = asetype
+		r.colConverters[i] = cmd.conn.typeConverter(asetype, int(r.dataFmts[i].usertype))
+	}
+
+	lobColumns, err := streamedColumns(r.colASEType, streamLOBs)
+	if err != nil {
+		r.Close()
+		return nil, err
+	}
+	r.lobColumns = lobColumns
+
+	// Multiple rows are fetched at once if all columns are bound to
+	// memory. Streamed LOBs are read per row and locators are
+	// rebound for each row.
+	r.batchSize = 1
+	if cmd.conn != nil && cmd.conn.driverCtx.info.FetchBatchSize > 1 {
+		r.batchSize = cmd.conn.driverCtx.info.FetchBatchSize
+		for i := 0; i < r.numCols; i++ {
+			if r.lobColumns[i] || isLocatorType(r.colASEType[i]) {
+				r.batchSize = 1
+				break
+			}
+		}
+	}
 
-		// Set padding for datatypes that support it.
+	// Allocate memory for the copied lengths and indicators of all
+	// columns and rows of a batch.
+	n := r.numCols * r.batchSize
+	r.colCopied = unsafe.Slice((*int32)(r.alloc(n*C.sizeof_CS_INT)), n)
+	r.colIndicators = unsafe.Slice((*int16)(r.alloc(n*C.sizeof_CS_SMALLINT)), n)
+	r.colStrides = make([]int, r.numCols)
+
+	// Setup column and row memory for ct to write into
+	for i := 0; i < r.numCols; i++ {
+		if r.lobColumns[i] {
+			continue
+		}
+
+		r.dataFmts[i].datatype = (C.CS_INT)(bindType((ASEType)(r.dataFmts[i].datatype)))
+
+		// Character and binary data is not padded, so that the copied
+		// length is the length of the value instead of maxlength.
 		switch r.dataFmts[i].datatype {
 		case C.CS_BINARY_TYPE, C.CS_LONGBINARY_TYPE, C.CS_VARBINARY_TYPE, C.CS_CHAR_TYPE, C.CS_VARCHAR_TYPE, C.CS_LONGCHAR_TYPE:
-			r.dataFmts[i].format = C.CS_FMT_PADNULL
-		case C.CS_XML_TYPE:
-			r.dataFmts[i].format = C.CS_FMT_NULLTERM
-		case C.CS_TEXT_TYPE, C.CS_UNITEXT_TYPE:
-			r.dataFmts[i].format = C.CS_FMT_NULLTERM
+			r.dataFmts[i].format = C.CS_FMT_UNUSED
 		}
 
+		// Locators are bound to a CS_LOCATOR instead of memory.
+		if isLocatorType(r.colASEType[i]) {
+			if err := r.bindLocator(i); err != nil {
+				r.Close()
+				return nil, err
+			}
+			continue
+		}
+
+		// The values of a column are stored consecutively with the
+		// size of the C representation of fixed-length types
+		// respectively maxlength.
+		switch size := fixedByteSize(r.colASEType[i]); {
+		case r.colASEType[i] == DECIMAL || r.colASEType[i] == NUMERIC:
+			r.dataFmts[i].maxlength = C.sizeof_CS_DECIMAL
+		case size > 0 && r.colFallback[i] == 0:
+			r.dataFmts[i].maxlength = (C.CS_INT)(size)
+		}
+		r.colStrides[i] = int(r.dataFmts[i].maxlength)
+		r.dataFmts[i].count = (C.CS_INT)(r.batchSize)
+
 		// Allocate memory according maxlength of column
-		r.colData[i] = C.calloc((C.ulong)(r.dataFmts[i].maxlength), C.sizeof_CS_BYTE)
+		size := r.colStrides[i] * r.batchSize
+		data := r.alloc(size)
+		r.colData[i] = unsafe.Slice((*byte)(data), size)
 
 		// Bind colData as the target for the data fetched with ct_fetch.
-		// The last two arguments - copied and indicator - are optional.
-		retval = C.ct_bind(cmd.cmd, (C.CS_INT)(i+1), r.dataFmts[i], r.colData[i], nil, nil)
+		// The lengths of the data are written to copied and null
+		// values are signalled by the indicators.
+		offset := i * r.batchSize
+		retval := C.ct_bind(cmd.cmd, (C.CS_INT)(i+1), r.dataFmts[i], data,
+			(*C.CS_INT)(unsafe.Pointer(&r.colCopied[offset])), (*C.CS_SMALLINT)(unsafe.Pointer(&r.colIndicators[offset])))
 		if retval != C.CS_SUCCEED {
 			r.Close()
 			return nil, makeError(retval, "Failed to bind data")
@@ -114,15 +271,72 @@ func newRows(cmd *Command) (*Rows, error) {
 	return r, nil
 }
 
+// bindType returns the type columns of the passed ASEType are bound as.
+//
+// Types without a fixed C representation are bound as character or
+// binary data. CS_VARCHAR and CS_VARBINARY are structures with a length
+// prefix and a fixed size of CS_MAX_CHAR, which would truncate longer
+// values.
+func bindType(t ASEType) ASEType {
+	switch t {
+	case VARCHAR, SENSITIVITY, BOUNDARY:
+		return CHAR
+	case VARBINARY, USER:
+		return BINARY
+	case BLOB:
+		return LONGBINARY
+	default:
+		return t
+	}
+}
+
+// alloc allocates zeroed C memory of the passed size, which is released
+// when the rows are freed.
+func (rows *Rows) alloc(size int) unsafe.Pointer {
+	// calloc may return nil for a size of zero.
+	if size < 1 {
+		size = 1
+	}
+
+	ptr := C.calloc((C.ulong)(size), 1)
+	rows.allocs = append(rows.allocs, ptr)
+	return ptr
+}
+
+// bindLocator allocates a new locator and binds it to the column with
+// the passed index.
+func (rows *Rows) bindLocator(index int) error {
+	loc, err := newLocator(rows.cmd.conn, rows.colASEType[index])
+	if err != nil {
+		return err
+	}
+
+	// Locator columns are fetched row by row, the indicator signals
+	// null values.
+	retval := C.ct_bind(rows.cmd.cmd, (C.CS_INT)(index+1), rows.dataFmts[index], unsafe.Pointer(loc.loc), nil,
+		(*C.CS_SMALLINT)(unsafe.Pointer(&rows.colIndicators[index*rows.batchSize])))
+	if retval != C.CS_SUCCEED {
+		loc.Close()
+		return makeError(retval, "Failed to bind locator")
+	}
+
+	loc.rows = rows
+	rows.colLocators[index] = loc
+	return nil
+}
+
 // Close implements the driver.Rows interface.
 func (rows *Rows) Close() error {
-	for i := 0; i < rows.numCols; i++ {
-		if rows.dataFmts[i] != nil {
-			C.free(unsafe.Pointer(rows.dataFmts[i]))
-		}
-		if rows.colData[i] != nil {
-			C.free(rows.colData[i])
-		}
+	// The bound memory is released even if the command was already
+	// released, e.g. by discard.
+	rows.free()
+
+	if rows.cmd == nil {
+		return nil
+	}
+
+	if rows.inResults {
+		return rows.discard()
 	}
 
 	retval := C.ct_cancel(nil, rows.cmd.cmd, C.CS_CANCEL_ALL)
@@ -140,84 +354,329 @@ func (rows *Rows) Close() error {
 	return nil
 }
 
+// discard discards the remaining rows of the result set without
+// cancelling the following results of the command.
+func (rows *Rows) discard() error {
+	cmd := rows.cmd
+	rows.cmd = nil
+
+	if rows.done {
+		return nil
+	}
+
+	retval := C.ct_cancel(nil, cmd.cmd, C.CS_CANCEL_CURRENT)
+	if retval != C.CS_SUCCEED {
+		return makeError(retval, "Error discarding result set")
+	}
+
+	return nil
+}
+
+// free releases the memory allocated for the bound columns. Released
+// memory is reset, so that free can be called multiple times.
+func (rows *Rows) free() {
+	for i, dataFmt := range rows.dataFmts {
+		if dataFmt != nil {
+			C.free(unsafe.Pointer(dataFmt))
+			rows.dataFmts[i] = nil
+		}
+	}
+
+	for i, locator := range rows.colLocators {
+		if locator != nil {
+			locator.Close()
+			rows.colLocators[i] = nil
+		}
+	}
+
+	for _, ptr := range rows.allocs {
+		C.free(ptr)
+	}
+	rows.allocs = nil
+	rows.colData, rows.colCopied, rows.colIndicators = nil, nil, nil
+}
+
 // Columns implements the driver.Rows interface.
 func (rows *Rows) Columns() []string {
-	ret := make([]string, rows.numCols)
+	return append([]string(nil), rows.colNames...)
+}
+
+// ReturnStatus returns the return status of the last stored procedure
+// executed by the command the rows were returned for. The second
+// return value is false if no stored procedure was executed.
+//
+// Return statuses are sent after the result sets of a procedure. Once
+// all rows were read the remaining results of the command are read to
+// receive the return status, further result sets are discarded.
+func (rows *Rows) ReturnStatus() (int32, bool, error) {
+	cmd := rows.cmd
+	if cmd == nil {
+		return 0, false, errors.New("Rows are closed")
+	}
 
-	for i, col := range rows.dataFmts {
-		ret[i] = C.GoString(&((*C.CS_DATAFMT)(col).name[0]))
+	if rows.done && !rows.inResults && !rows.drained {
+		rows.drained = true
+		for {
+			next, _, _, err := cmd.Response()
+			if err != nil {
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				return 0, false, err
+			}
+
+			if next != nil {
+				// Only discard the current result set.
+				next.inResults = true
+				if err := next.Close(); err != nil {
+					return 0, false, err
+				}
+			}
+		}
 	}
 
-	return ret
+	return cmd.returnStatus, cmd.hasReturnStatus, nil
 }
 
 // Next implements the driver.Rows interface.
 func (rows *Rows) Next(dest []driver.Value) error {
-	retval := C.ct_fetch(rows.cmd.cmd, C.CS_UNUSED, C.CS_UNUSED, C.CS_UNUSED, nil)
+	if err := rows.advance(); err != nil {
+		return err
+	}
+
+	rows.rowNumber++
+	rows.lobItem = 0
+
+	for i := 0; i < rows.numCols; i++ {
+		val, err := rows.value(i)
+		if err != nil {
+			return err
+		}
+		dest[i] = val
+	}
+
+	return nil
+}
+
+// value returns the value of the column with the passed index in the
+// current row.
+func (rows *Rows) value(i int) (driver.Value, error) {
+	if rows.lobColumns[i] {
+		return &LOBReader{rows: rows, item: i + 1, row: rows.rowNumber}, nil
+	}
+
+	// Hand out the fetched locator and bind a new one for the next
+	// row. The bound locator is kept for null values.
+	if rows.colLocators[i] != nil {
+		if rows.isNull(i) {
+			return nil, nil
+		}
+
+		locator := rows.colLocators[i]
+		rows.colLocators[i] = nil
+		if err := rows.bindLocator(i); err != nil {
+			return nil, err
+		}
+		return locator, nil
+	}
+
+	if rows.isNull(i) {
+		return nil, nil
+	}
+
+	if fallback := rows.colFallback[i]; fallback != 0 {
+		bs, err := csConvert(rows.cmd.conn.driverCtx.ctx, rows.columnBytes(i), rows.colASEType[i], fallback)
+		if err != nil {
+			return nil, fmt.Errorf("Error converting column %d: %w", i+1, err)
+		}
+
+		if fallback == CHAR {
+			return string(bs), nil
+		}
+		return bs, nil
+	}
+
+	if conv := rows.colConverters[i]; conv != nil {
+		val, err := conv.GoValue(rows.columnBytes(i))
+		if err != nil {
+			return nil, fmt.Errorf("Error converting column %d: %w", i+1, err)
+		}
+		return val, nil
+	}
+
+	val, err := columnValue(rows.colASEType[i], rows.columnBytes(i))
+	if err != nil {
+		return nil, err
+	}
+
+	if rows.native != nil && val != nil {
+		val, err = rows.native.goValue(rows.colASEType[i], val)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if s, ok := val.(string); ok {
+		val = rows.trim(i, s)
+	}
+
+	return val, nil
+}
+
+// trim removes trailing blanks from values of fixed-length character
+// columns if trimChar is set.
+func (rows *Rows) trim(i int, s string) string {
+	if !rows.trimChar {
+		return s
+	}
+
+	switch rows.colASEType[i] {
+	case CHAR, UNICHAR:
+		return strings.TrimRight(s, " ")
+	}
+
+	return s
+}
+
+// advance moves to the next row, fetching the next batch of rows when
+// all rows of the current batch were read.
+func (rows *Rows) advance() error {
+	if rows.current+1 < rows.fetched {
+		rows.current++
+		return nil
+	}
+
+	fetched, err := rows.fetch()
+	if err != nil {
+		if err == io.EOF {
+			rows.fetched, rows.current = 0, 0
+			rows.done = true
+		}
+		return err
+	}
+
+	rows.fetched, rows.current = fetched, 0
+	return nil
+}
+
+// ctFetch fetches the next batch of rows with ct_fetch.
+func (rows *Rows) ctFetch() (int, error) {
+	var rowsRead C.CS_INT
+	retval := C.ct_fetch(rows.cmd.cmd, C.CS_UNUSED, C.CS_UNUSED, C.CS_UNUSED, &rowsRead)
 	switch retval {
 	case C.CS_SUCCEED:
-		break
+		return int(rowsRead), nil
 	case C.CS_END_DATA:
-		return io.EOF
+		return 0, io.EOF
 	case C.CS_ROW_FAIL, C.CS_FAIL:
-		return makeError(retval, "Failed to retrieve rows")
+		return 0, makeError(retval, "Failed to retrieve rows")
+	default:
+		return 0, makeError(retval, "Failed to fetch next row")
 	}
+}
 
-	if retval != C.CS_SUCCEED {
-		return makeError(retval, "Failed to fetch next row")
+// isNull returns true if the value of the column with the passed index
+// in the current row is null.
+func (rows *Rows) isNull(index int) bool {
+	return rows.colIndicators[index*rows.batchSize+rows.current] == -1
+}
+
+// columnBytes copies the data of the column with the passed index in
+// the current row from the bound C memory.
+func (rows *Rows) columnBytes(index int) []byte {
+	if rows.colASEType[index] == VOID {
+		return nil
 	}
 
-	for i := 0; i < len(rows.colData); i++ {
-		dataType := rows.colASEType[i].ToDataType()
+	stride := rows.colStrides[index]
+	data := rows.colData[index][rows.current*stride : rows.current*stride+stride]
 
-		switch rows.colASEType[i] {
-		case BIGINT, INT, SMALLINT, TINYINT, UBIGINT, UINT, USMALLINT, USHORT, FLOAT, REAL, BIT, MONEY, MONEY4, DATE, TIME, DATETIME, DATETIME4:
-			bs := C.GoBytes(rows.colData[i], C.int(dataType.ByteSize()))
-			val, err := dataType.GoValue(binary.LittleEndian, bs)
-			if err != nil {
-				return err
-			}
-			dest[i] = val
-		case CHAR, VARCHAR, TEXT, LONGCHAR:
-			dest[i] = C.GoString((*C.char)(rows.colData[i]))
-		case BINARY, IMAGE:
-			dest[i] = C.GoBytes(rows.colData[i], rows.dataFmts[i].maxlength)
-		case DECIMAL, NUMERIC:
-			csDec := (*C.CS_DECIMAL)(rows.colData[i])
-			bs := C.GoBytes(
-				unsafe.Pointer(&csDec.array),
-				(C.int)(asetypes.DecimalByteSize(int(csDec.precision))),
-			)
-
-			decI, err := dataType.GoValue(binary.LittleEndian, bs)
-			if err != nil {
-				return err
-			}
+	size := int(rows.colCopied[index*rows.batchSize+rows.current])
+	switch fixed := fixedByteSize(rows.colASEType[index]); {
+	case rows.colASEType[index] == DECIMAL || rows.colASEType[index] == NUMERIC:
+		size = C.sizeof_CS_DECIMAL
+	case fixed > 0 && rows.colFallback[index] == 0:
+		size = fixed
+	}
 
-			dec := decI.(*asetypes.Decimal)
-			dec.Precision = int(csDec.precision)
-			dec.Scale = int(csDec.scale)
-			dest[i] = dec
-		case BIGDATETIME, BIGTIME:
-			bs := C.GoBytes(rows.colData[i], 8)
-			resp, err := dataType.GoValue(binary.LittleEndian, bs)
-			if err != nil {
-				return err
-			}
-			dest[i] = resp
-		case UNICHAR, UNITEXT:
-			b := C.GoBytes(rows.colData[i], rows.dataFmts[i].maxlength)
-			s, err := dataType.GoValue(binary.LittleEndian, b)
-			if err != nil {
-				return err
-			}
-			dest[i] = s
-		default:
-			return fmt.Errorf("Unhandled Go type: %+v", rows.colASEType[i])
-		}
+	if size > len(data) {
+		size = len(data)
 	}
 
-	return nil
+	bs := make([]byte, size)
+	copy(bs, data)
+	return bs
+}
+
+// fixedByteSize returns the size of the C representation of fixed-length
+// ASETypes and -1 for all other types.
+func fixedByteSize(t ASEType) int {
+	switch t {
+	case BIGDATETIME, BIGTIME:
+		// BIGDATETIMEN and BIGTIMEN are nullable and have no fixed
+		// size in TDS, Client-Library returns them as CS_BIGINT.
+		return 8
+	default:
+		return t.ToDataType().ByteSize()
+	}
+}
+
+// columnValue converts the data Client-Library copied into the bound
+// memory of a column of the passed ASEType into a Go value.
+//
+// bs must contain exactly the copied bytes of variable-length types.
+func columnValue(t ASEType, bs []byte) (driver.Value, error) {
+	dataType := t.ToDataType()
+
+	switch t {
+	case BIGINT, LONG, INT, SMALLINT, TINYINT, UBIGINT, UINT, USMALLINT, USHORT, FLOAT, REAL, BIT, MONEY, MONEY4, DATE, TIME, DATETIME, DATETIME4, BIGDATETIME, BIGTIME:
+		size := fixedByteSize(t)
+		if len(bs) < size {
+			return nil, fmt.Errorf("Received %d bytes for %s, expected %d", len(bs), t, size)
+		}
+		return dataType.GoValue(binary.LittleEndian, bs[:size])
+	case CHAR, VARCHAR, TEXT, LONGCHAR, SENSITIVITY, BOUNDARY:
+		return string(bs), nil
+	case XML:
+		return bs, nil
+	case BINARY, IMAGE, VARBINARY, LONGBINARY, BLOB, USER:
+		return bs, nil
+	case TIMESTAMP:
+		var ts Timestamp
+		if len(bs) < len(ts) {
+			return nil, fmt.Errorf("Received %d bytes for %s, expected %d", len(bs), t, len(ts))
+		}
+		copy(ts[:], bs)
+		return ts, nil
+	case DECIMAL, NUMERIC:
+		// The bytes are a CS_DECIMAL - precision and scale followed
+		// by the sign and the value.
+		if len(bs) < 2 {
+			return nil, fmt.Errorf("Received %d bytes for %s, expected at least 2", len(bs), t)
+		}
+
+		precision, scale := int(bs[0]), int(bs[1])
+		size := asetypes.DecimalByteSize(precision)
+		if len(bs) < 2+size {
+			return nil, fmt.Errorf("Received %d bytes for %s with precision %d, expected %d", len(bs), t, precision, 2+size)
+		}
+
+		decI, err := dataType.GoValue(binary.LittleEndian, bs[2:2+size])
+		if err != nil {
+			return nil, err
+		}
+
+		dec := decI.(*asetypes.Decimal)
+		dec.Precision = precision
+		dec.Scale = scale
+		return dec, nil
+	case UNICHAR, UNITEXT, UNIVARCHAR:
+		return decodeUTF16(bs), nil
+	case VOID:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("Unhandled Go type: %+v", t)
+	}
 }
 
 // ColumnTypeDatabaseTypeName implements the
@@ -249,6 +708,11 @@ func (rows *Rows) ColumnTypeLength(index int) (int64, bool) {
 		return int64(rows.dataFmts[index].maxlength), true
 	case CHAR:
 		return int64(C.CS_MAX_CHAR), true
+	case TIMESTAMP:
+		return int64(len(Timestamp{})), true
+	case UNICHAR, UNIVARCHAR:
+		// maxlength is the length in bytes of UTF-16 code units.
+		return int64(rows.dataFmts[index].maxlength / 2), true
 	default:
 		return 0, false
 	}
@@ -262,5 +726,37 @@ func (rows *Rows) ColumnTypeMaxLength(index int) int64 {
 
 // ColumnTypeScanType returns the datatype of the column.
 func (rows *Rows) ColumnTypeScanType(index int) reflect.Type {
+	if rows.lobColumns[index] {
+		return reflect.TypeOf((*io.Reader)(nil)).Elem()
+	}
+
+	if conv := rows.colConverters[index]; conv != nil {
+		return conv.ScanType()
+	}
+
+	switch rows.colFallback[index] {
+	case CHAR:
+		return reflect.TypeOf("")
+	case BINARY:
+		return reflect.TypeOf([]byte{})
+	}
+
+	if rows.native != nil {
+		if t := rows.native.scanType(rows.colASEType[index]); t != nil {
+			return t
+		}
+	}
+
+	switch rows.colASEType[index] {
+	case TEXTLOCATOR, IMAGELOCATOR, UNITEXTLOCATOR:
+		return reflect.TypeOf(&Locator{})
+	case SENSITIVITY, BOUNDARY:
+		return reflect.TypeOf("")
+	case USER:
+		return reflect.TypeOf([]byte{})
+	case TIMESTAMP:
+		return reflect.TypeOf(Timestamp{})
+	}
+
 	return rows.colASEType[index].ToDataType().GoReflectType()
 }
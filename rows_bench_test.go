@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// +build integration
+
+package ase
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+)
+
+// benchmarkQuery returns a few thousand rows of mixed types.
+const benchmarkQuery = "select a.id, a.name, a.crdate from master..sysobjects a, master..sysobjects b"
+
+func BenchmarkFetch(b *testing.B) {
+	for _, size := range []int{1, 10, 100, 1000} {
+		b.Run(fmt.Sprintf("batch-%d", size), func(b *testing.B) {
+			benchmarkFetch(b, size)
+		})
+	}
+}
+
+func benchmarkFetch(b *testing.B, size int) {
+	info, err := NewInfoWithEnv()
+	if err != nil {
+		b.Fatalf("error reading info from environment: %v", err)
+	}
+	info.FetchBatchSize = size
+
+	connector, err := NewConnector(info)
+	if err != nil {
+		b.Fatalf("error creating connector: %v", err)
+	}
+
+	db := sql.OpenDB(connector)
+	defer db.Close()
+
+	var (
+		id     int64
+		name   string
+		crdate interface{}
+	)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		rows, err := db.Query(benchmarkQuery)
+		if err != nil {
+			b.Fatalf("error querying: %v", err)
+		}
+
+		for rows.Next() {
+			if err := rows.Scan(&id, &name, &crdate); err != nil {
+				b.Fatalf("error scanning: %v", err)
+			}
+		}
+
+		if err := rows.Err(); err != nil {
+			b.Fatalf("error iterating rows: %v", err)
+		}
+		rows.Close()
+	}
+}
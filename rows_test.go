@@ -0,0 +1,545 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/SAP/go-dblib/asetime"
+	"github.com/SAP/go-dblib/asetypes"
+)
+
+// le returns the little endian representation of the passed values as
+// Client-Library copies them into bound memory.
+func le(values ...interface{}) []byte {
+	buf := &bytes.Buffer{}
+	for _, v := range values {
+		if err := binary.Write(buf, binary.LittleEndian, v); err != nil {
+			panic(err)
+		}
+	}
+	return buf.Bytes()
+}
+
+// csDecimal returns the bytes of a CS_DECIMAL with the passed precision,
+// scale, sign and magnitude.
+func csDecimal(precision, scale int, negative bool, magnitude ...byte) []byte {
+	// precision + scale + array[CS_MAX_NUMLEN]
+	bs := make([]byte, 2+33)
+	bs[0] = byte(precision)
+	bs[1] = byte(scale)
+
+	size := asetypes.DecimalByteSize(precision)
+	if negative {
+		bs[2] = 0x1
+	}
+	copy(bs[2+size-len(magnitude):], magnitude)
+
+	return bs
+}
+
+func mustDecimal(precision, scale int, s string) *asetypes.Decimal {
+	dec, err := asetypes.NewDecimalString(precision, scale, s)
+	if err != nil {
+		panic(err)
+	}
+	return dec
+}
+
+func TestColumnValue(t *testing.T) {
+	cases := map[ASEType]struct {
+		bs    []byte
+		value driver.Value
+	}{
+		BIGINT:    {le(int64(math.MinInt64)), int64(math.MinInt64)},
+		LONG:      {le(int64(-5)), int64(-5)},
+		INT:       {le(int32(math.MaxInt32)), int32(math.MaxInt32)},
+		SMALLINT:  {le(int16(-300)), int16(-300)},
+		TINYINT:   {le(uint8(255)), uint8(255)},
+		UBIGINT:   {le(uint64(math.MaxUint64)), uint64(math.MaxUint64)},
+		UINT:      {le(uint32(math.MaxUint32)), uint32(math.MaxUint32)},
+		USMALLINT: {le(uint16(65535)), uint16(65535)},
+		USHORT:    {le(uint16(42)), uint16(42)},
+		FLOAT:     {le(float64(1.5)), float64(1.5)},
+		REAL:      {le(float32(-2.25)), float32(-2.25)},
+		BIT:       {[]byte{0x1}, true},
+		MONEY: {
+			le(int32(0), uint32(12345)),
+			mustDecimal(asetypes.ASEMoneyPrecision, asetypes.ASEMoneyScale, "1.2345"),
+		},
+		MONEY4: {
+			le(int32(-12345)),
+			mustDecimal(asetypes.ASEShortMoneyPrecision, asetypes.ASEShortMoneyScale, "-1.2345"),
+		},
+		DATE:      {le(int32(1)), time.Date(1900, time.January, 2, 0, 0, 0, 0, time.UTC)},
+		TIME:      {le(int32(300)), time.Date(1, time.January, 1, 0, 0, 1, 0, time.UTC)},
+		DATETIME:  {le(int32(1), int32(300)), time.Date(1900, time.January, 2, 0, 0, 1, 0, time.UTC)},
+		DATETIME4: {le(uint16(1), uint16(1)), time.Date(1900, time.January, 2, 0, 1, 0, 0, time.UTC)},
+		BIGDATETIME: {
+			le(uint64(asetime.Day)),
+			time.Date(0, time.January, 2, 0, 0, 0, 0, time.UTC),
+		},
+		BIGTIME: {
+			le(uint64(time.Second / time.Microsecond)),
+			asetime.EpochRataDie().Add(time.Second),
+		},
+		DECIMAL:     {csDecimal(10, 2, false, 0x30, 0x39), mustDecimal(10, 2, "123.45")},
+		NUMERIC:     {csDecimal(5, 0, true, 0x7b), mustDecimal(5, 0, "-123")},
+		CHAR:        {[]byte("char  "), "char  "},
+		VARCHAR:     {[]byte("var\x00char"), "var\x00char"},
+		LONGCHAR:    {[]byte("longchar"), "longchar"},
+		TEXT:        {[]byte("text"), "text"},
+		SENSITIVITY: {[]byte("label"), "label"},
+		BOUNDARY:    {[]byte("bound"), "bound"},
+		XML:         {[]byte("<a/>"), []byte("<a/>")},
+		BINARY:      {[]byte{0xde, 0xad, 0x00}, []byte{0xde, 0xad, 0x00}},
+		VARBINARY:   {[]byte{0xbe, 0xef}, []byte{0xbe, 0xef}},
+		LONGBINARY:  {[]byte{0x00, 0x01}, []byte{0x00, 0x01}},
+		IMAGE:       {[]byte{0xca, 0xfe}, []byte{0xca, 0xfe}},
+		BLOB:        {[]byte{0xff}, []byte{0xff}},
+		USER:        {[]byte{0x01, 0x02}, []byte{0x01, 0x02}},
+		UNICHAR:     {le(uint16('a'), uint16(0xe4), uint16(0)), "aä\x00"},
+		UNITEXT:     {le(uint16(0xd83d), uint16(0xde00)), "\U0001F600"},
+		VOID:        {nil, nil},
+		TIMESTAMP: {
+			[]byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x02, 0x03},
+			Timestamp{0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x02, 0x03},
+		},
+		UNIVARCHAR: {le(uint16('x'), uint16(0x20ac)), "x€"},
+	}
+
+	for asetype := range usertype2string {
+		if _, ok := cases[asetype]; !ok {
+			t.Errorf("No test case for ASEType %s", asetype)
+		}
+	}
+
+	for asetype := range type2string {
+		if _, ok := cases[asetype]; ok {
+			continue
+		}
+
+		switch asetype {
+		case TEXTLOCATOR, IMAGELOCATOR, UNITEXTLOCATOR:
+			// Locators are bound to a CS_LOCATOR and not converted.
+			continue
+		}
+
+		t.Errorf("No test case for ASEType %s", asetype)
+	}
+
+	for aseType, tc := range cases {
+		tc := tc
+		t.Run(aseType.String(), func(t *testing.T) {
+			value, err := columnValue(aseType, tc.bs)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if want, ok := tc.value.(*asetypes.Decimal); ok {
+				got, ok := value.(*asetypes.Decimal)
+				if !ok {
+					t.Fatalf("Expected *asetypes.Decimal, received %T", value)
+				}
+
+				if got.String() != want.String() || got.Precision != want.Precision || got.Scale != want.Scale {
+					t.Errorf("Expected %s(%d, %d), received %s(%d, %d)",
+						want, want.Precision, want.Scale, got, got.Precision, got.Scale)
+				}
+				return
+			}
+
+			if !reflect.DeepEqual(value, tc.value) {
+				t.Errorf("Expected %#v, received %#v", tc.value, value)
+			}
+		})
+	}
+}
+
+// fakeValue is the value of a column in a row returned by fakeRows.
+type fakeValue struct {
+	data []byte
+	null bool
+}
+
+// fakeRows returns rows with columns of the passed types, which are
+// fetched batchSize rows at a time as Client-Library fetches them into
+// the bound memory. strides is the size of the bound memory of each
+// column.
+func fakeRows(types []ASEType, strides []int, batchSize int, values [][]fakeValue) *Rows {
+	numCols := len(types)
+	rows := &Rows{
+		numCols:       numCols,
+		colNames:      make([]string, numCols),
+		colASEType:    types,
+		colData:       make([][]byte, numCols),
+		colCopied:     make([]int32, numCols*batchSize),
+		colIndicators: make([]int16, numCols*batchSize),
+		colStrides:    strides,
+		batchSize:     batchSize,
+		colConverters: make([]TypeConverter, numCols),
+		colFallback:   make([]ASEType, numCols),
+		colLocators:   make([]*Locator, numCols),
+		lobColumns:    make([]bool, numCols),
+	}
+
+	for i := range types {
+		rows.colNames[i] = fmt.Sprintf("col%d", i+1)
+		rows.colData[i] = make([]byte, strides[i]*batchSize)
+	}
+
+	next := 0
+	rows.fetch = func() (int, error) {
+		if next >= len(values) {
+			return 0, io.EOF
+		}
+
+		count := len(values) - next
+		if count > batchSize {
+			count = batchSize
+		}
+
+		for row := 0; row < count; row++ {
+			for i, value := range values[next+row] {
+				data := rows.colData[i][row*strides[i] : (row+1)*strides[i]]
+				for j := range data {
+					data[j] = 0
+				}
+
+				rows.colIndicators[i*batchSize+row] = 0
+				if value.null {
+					rows.colIndicators[i*batchSize+row] = -1
+				}
+				rows.colCopied[i*batchSize+row] = int32(copy(data, value.data))
+			}
+		}
+
+		next += count
+		return count, nil
+	}
+
+	return rows
+}
+
+func TestAsRows(t *testing.T) {
+	var typedNil *Rows
+	rows := &Rows{}
+
+	cases := map[string]struct {
+		rows driver.Rows
+		ok   bool
+	}{
+		"nil":       {nil, false},
+		"typed nil": {typedNil, false},
+		"rows":      {rows, true},
+	}
+
+	for title, cas := range cases {
+		t.Run(title, func(t *testing.T) {
+			r, ok := asRows(cas.rows)
+			if ok != cas.ok {
+				t.Fatalf("Expected %t, received %t", cas.ok, ok)
+			}
+			if ok && r != rows {
+				t.Errorf("Expected %p, received %p", rows, r)
+			}
+		})
+	}
+}
+
+func TestBindType(t *testing.T) {
+	cases := map[ASEType]ASEType{
+		VARCHAR:     CHAR,
+		SENSITIVITY: CHAR,
+		BOUNDARY:    CHAR,
+		VARBINARY:   BINARY,
+		USER:        BINARY,
+		BLOB:        LONGBINARY,
+		CHAR:        CHAR,
+		BINARY:      BINARY,
+		LONGCHAR:    LONGCHAR,
+		INT:         INT,
+		VOID:        VOID,
+	}
+
+	for asetype, expected := range cases {
+		if recv := bindType(asetype); recv != expected {
+			t.Errorf("%s: expected %s, received %s", asetype, expected, recv)
+		}
+	}
+}
+
+func TestRowsNextBoundTypes(t *testing.T) {
+	cases := map[string]struct {
+		asetype ASEType
+		stride  int
+		data    []byte
+		value   driver.Value
+	}{
+		"varchar bound as char":     {VARCHAR, 32, []byte("abc"), "abc"},
+		"varbinary bound as binary": {VARBINARY, 16, []byte{0x1, 0x0, 0x2}, []byte{0x1, 0x0, 0x2}},
+		"user bound as binary":      {USER, 8, []byte{0xff}, []byte{0xff}},
+		"blob bound as longbinary":  {BLOB, 64, []byte{0xca, 0xfe}, []byte{0xca, 0xfe}},
+		"empty varchar":             {VARCHAR, 32, []byte{}, ""},
+		"void":                      {VOID, 0, nil, nil},
+	}
+
+	for title, cas := range cases {
+		t.Run(title, func(t *testing.T) {
+			rows := fakeRows([]ASEType{cas.asetype}, []int{cas.stride}, 1, [][]fakeValue{{{data: cas.data}}})
+
+			dest := make([]driver.Value, 1)
+			if err := rows.Next(dest); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(dest[0], cas.value) {
+				t.Errorf("Expected %#v, received %#v", cas.value, dest[0])
+			}
+
+			if err := rows.Next(dest); err != io.EOF {
+				t.Errorf("Expected io.EOF, received %v", err)
+			}
+		})
+	}
+}
+
+func TestRowsNextNullLocator(t *testing.T) {
+	rows := fakeRows([]ASEType{TEXTLOCATOR}, []int{0}, 1, [][]fakeValue{{{null: true}}})
+	loc := &Locator{Type: TEXTLOCATOR, rows: rows}
+	rows.colLocators[0] = loc
+
+	dest := make([]driver.Value, 1)
+	if err := rows.Next(dest); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if dest[0] != nil {
+		t.Errorf("Expected nil for null locator, received %#v", dest[0])
+	}
+
+	if rows.colLocators[0] != loc {
+		t.Errorf("Expected bound locator to be kept for the next row")
+	}
+}
+
+func TestRowsNextShortValueInWideColumn(t *testing.T) {
+	cases := map[string]struct {
+		asetype ASEType
+		data    []byte
+		value   driver.Value
+	}{
+		"char":       {CHAR, []byte("ab"), "ab"},
+		"varchar":    {VARCHAR, []byte("ab"), "ab"},
+		"longchar":   {LONGCHAR, []byte("ab"), "ab"},
+		"binary":     {BINARY, []byte{0x1, 0x2}, []byte{0x1, 0x2}},
+		"varbinary":  {VARBINARY, []byte{0x1, 0x2}, []byte{0x1, 0x2}},
+		"longbinary": {LONGBINARY, []byte{0x1, 0x2}, []byte{0x1, 0x2}},
+	}
+
+	for title, cas := range cases {
+		t.Run(title, func(t *testing.T) {
+			rows := fakeRows([]ASEType{cas.asetype}, []int{255}, 1, [][]fakeValue{{{data: cas.data}}})
+
+			dest := make([]driver.Value, 1)
+			if err := rows.Next(dest); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if length := len(rows.columnBytes(0)); length != len(cas.data) {
+				t.Errorf("Expected length %d, received %d", len(cas.data), length)
+			}
+
+			if !reflect.DeepEqual(dest[0], cas.value) {
+				t.Errorf("Expected %#v, received %#v", cas.value, dest[0])
+			}
+		})
+	}
+}
+
+func TestRowsAdvance(t *testing.T) {
+	cases := map[string]struct {
+		batchSize int
+		numRows   int
+		fetched   []int
+	}{
+		"row by row":         {1, 3, []int{1, 1, 1}},
+		"partial last batch": {2, 3, []int{2, 2, 1}},
+		"exact batches":      {2, 4, []int{2, 2, 2, 2}},
+		"single batch":       {5, 3, []int{3, 3, 3}},
+		"empty":              {2, 0, []int{}},
+	}
+
+	for title, cas := range cases {
+		t.Run(title, func(t *testing.T) {
+			values := make([][]fakeValue, cas.numRows)
+			for i := range values {
+				values[i] = []fakeValue{{data: le(int32(i))}}
+			}
+
+			rows := fakeRows([]ASEType{INT}, []int{4}, cas.batchSize, values)
+
+			calls := 0
+			fetch := rows.fetch
+			rows.fetch = func() (int, error) {
+				calls++
+				return fetch()
+			}
+
+			for i := 0; i < cas.numRows; i++ {
+				if err := rows.advance(); err != nil {
+					t.Fatalf("Row %d: unexpected error: %v", i, err)
+				}
+
+				if rows.fetched != cas.fetched[i] {
+					t.Errorf("Row %d: expected %d fetched rows, received %d", i, cas.fetched[i], rows.fetched)
+				}
+
+				if rows.current != i%cas.batchSize {
+					t.Errorf("Row %d: expected current %d, received %d", i, i%cas.batchSize, rows.current)
+				}
+
+				val, err := rows.value(0)
+				if err != nil {
+					t.Fatalf("Row %d: unexpected error: %v", i, err)
+				}
+				if val != int32(i) {
+					t.Errorf("Row %d: expected %d, received %#v", i, i, val)
+				}
+			}
+
+			if err := rows.advance(); err != io.EOF {
+				t.Fatalf("Expected io.EOF, received %v", err)
+			}
+
+			if !rows.done || rows.fetched != 0 || rows.current != 0 {
+				t.Errorf("Expected exhausted rows, received done=%t fetched=%d current=%d",
+					rows.done, rows.fetched, rows.current)
+			}
+
+			// One fetch per batch and one returning io.EOF.
+			expected := (cas.numRows+cas.batchSize-1)/cas.batchSize + 1
+			if calls != expected {
+				t.Errorf("Expected %d fetches, received %d", expected, calls)
+			}
+		})
+	}
+}
+
+func TestRowsNextNull(t *testing.T) {
+	rows := fakeRows([]ASEType{INT, VARCHAR}, []int{4, 16}, 2, [][]fakeValue{
+		{{data: le(int32(1))}, {null: true}},
+		{{null: true}, {data: []byte("b")}},
+		{{null: true}, {null: true}},
+	})
+
+	expected := [][]driver.Value{
+		{int32(1), nil},
+		{nil, "b"},
+		{nil, nil},
+	}
+
+	dest := make([]driver.Value, 2)
+	for i, row := range expected {
+		if err := rows.Next(dest); err != nil {
+			t.Fatalf("Row %d: unexpected error: %v", i, err)
+		}
+
+		if !reflect.DeepEqual(dest, row) {
+			t.Errorf("Row %d: expected %#v, received %#v", i, row, dest)
+		}
+	}
+
+	if err := rows.Next(dest); err != io.EOF {
+		t.Errorf("Expected io.EOF, received %v", err)
+	}
+}
+
+func TestRowsCloseReleased(t *testing.T) {
+	rows := fakeRows([]ASEType{INT}, []int{4}, 1, [][]fakeValue{{{data: le(int32(1))}}})
+	rows.alloc(8)
+	rows.colLocators[0] = &Locator{Type: TEXTLOCATOR, rows: rows}
+
+	// The rows were already released from the command, e.g. by
+	// discard, and must still free their memory.
+	for i := 0; i < 2; i++ {
+		if err := rows.Close(); err != nil {
+			t.Fatalf("Close %d: unexpected error: %v", i+1, err)
+		}
+
+		if rows.allocs != nil || rows.colData != nil || rows.colCopied != nil || rows.colIndicators != nil {
+			t.Errorf("Close %d: expected bound memory to be released", i+1)
+		}
+
+		if rows.colLocators[0] != nil {
+			t.Errorf("Close %d: expected locator to be released", i+1)
+		}
+	}
+}
+
+func TestRowsCloseInResults(t *testing.T) {
+	cmd := &Command{}
+	rows := fakeRows([]ASEType{INT}, []int{4}, 2, [][]fakeValue{
+		{{data: le(int32(1))}},
+		{{data: le(int32(2))}},
+	})
+	rows.cmd = cmd
+	rows.inResults = true
+	cmd.pending = rows
+
+	dest := make([]driver.Value, 1)
+	for {
+		if err := rows.Next(dest); err != nil {
+			if err != io.EOF {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			break
+		}
+	}
+
+	// All rows were read, closing the rows only releases the command
+	// without cancelling its remaining results.
+	if err := rows.Close(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if rows.cmd != nil {
+		t.Errorf("Expected rows to be released from the command")
+	}
+
+	if rows.colData != nil {
+		t.Errorf("Expected bound memory to be released")
+	}
+
+	if err := rows.Close(); err != nil {
+		t.Errorf("Unexpected error closing closed rows: %v", err)
+	}
+
+	if _, _, err := rows.ReturnStatus(); err == nil {
+		t.Errorf("Expected error reading return status of closed rows")
+	}
+}
+
+func TestRowsDiscardDone(t *testing.T) {
+	rows := fakeRows([]ASEType{INT}, []int{4}, 1, nil)
+	rows.cmd = &Command{}
+	rows.done = true
+
+	if err := rows.discard(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if rows.cmd != nil {
+		t.Errorf("Expected rows to be released from the command")
+	}
+}
@@ -0,0 +1,212 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+)
+
+// maxSavepointName is the maximum length of a savepoint name.
+const maxSavepointName = 255
+
+// checkSavepointName returns an error if name is not a valid identifier
+// and would have to be quoted.
+func checkSavepointName(name string) error {
+	if name == "" {
+		return errors.New("Savepoint name is empty")
+	}
+
+	if len(name) > maxSavepointName {
+		return fmt.Errorf("Savepoint name %q is longer than %d bytes", name, maxSavepointName)
+	}
+
+	if !isIdentifierStart(name[0]) || name[0] == '#' {
+		return fmt.Errorf("Savepoint name %q is not a valid identifier", name)
+	}
+
+	for i := 1; i < len(name); i++ {
+		if !isIdentifierChar(name[i]) {
+			return fmt.Errorf("Savepoint name %q is not a valid identifier", name)
+		}
+	}
+
+	return nil
+}
+
+// Savepoint sets a savepoint with the passed name in the active
+// transaction with SAVE TRANSACTION.
+//
+// Savepoint is accessible through sql.Conn.Raw.
+func (conn *Connection) Savepoint(ctx context.Context, name string) error {
+	if err := checkSavepointName(name); err != nil {
+		return err
+	}
+
+	if _, _, err := conn.GenericExec(ctx, "SAVE TRANSACTION "+name, nil); err != nil {
+		return fmt.Errorf("Failed to set savepoint %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// RollbackTo rolls back the active transaction to the savepoint with
+// the passed name. The transaction stays active.
+//
+// RollbackTo is accessible through sql.Conn.Raw.
+func (conn *Connection) RollbackTo(ctx context.Context, name string) error {
+	if err := checkSavepointName(name); err != nil {
+		return err
+	}
+
+	if _, _, err := conn.GenericExec(ctx, "ROLLBACK TRANSACTION "+name, nil); err != nil {
+		return fmt.Errorf("Failed to roll back to savepoint %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// TransactionCount returns the nesting level of transactions of the
+// connection as reported by @@trancount.
+func (conn *Connection) TransactionCount(ctx context.Context) (int, error) {
+	rows, _, err := conn.GenericExec(ctx, "select @@trancount", nil)
+	if err != nil {
+		return 0, fmt.Errorf("Failed to read @@trancount: %w", err)
+	}
+
+	r, ok := asRows(rows)
+	if !ok {
+		return 0, errors.New("Failed to read @@trancount: no result set returned")
+	}
+	defer r.Close()
+
+	values := make([]driver.Value, 1)
+	if err := r.Next(values); err != nil {
+		return 0, fmt.Errorf("Failed to read @@trancount: %w", err)
+	}
+
+	// The count is returned as int64 with native types.
+	switch typed := values[0].(type) {
+	case int32:
+		return int(typed), nil
+	case int64:
+		return int(typed), nil
+	default:
+		return 0, fmt.Errorf("Failed to read @@trancount: received value of type %T", values[0])
+	}
+}
+
+// NestedTx is a transaction which is either the outermost transaction
+// of the connection or a savepoint in the active transaction.
+type NestedTx struct {
+	conn *Connection
+	// savepoint is the name of the savepoint of an inner transaction,
+	// empty for the outermost transaction.
+	savepoint string
+}
+
+// BeginNested begins a transaction if the connection has no active
+// transaction. Otherwise a savepoint is set in the active transaction,
+// e.g. one started with sql.DB.BeginTx, and the returned NestedTx
+// commits and rolls back relative to the savepoint.
+//
+// This allows to compose functions which each run in a transaction.
+func (conn *Connection) BeginNested(ctx context.Context) (*NestedTx, error) {
+	count, err := conn.TransactionCount(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if count == 0 {
+		if _, _, err := conn.GenericExec(ctx, "BEGIN TRANSACTION", nil); err != nil {
+			return nil, fmt.Errorf("Failed to start transaction: %w", err)
+		}
+		return &NestedTx{conn: conn}, nil
+	}
+
+	conn.savepointSeq++
+	name := fmt.Sprintf("cgoase_sp%d", conn.savepointSeq)
+	if err := conn.Savepoint(ctx, name); err != nil {
+		return nil, err
+	}
+
+	return &NestedTx{conn: conn, savepoint: name}, nil
+}
+
+// Nested reports if the transaction is a savepoint in an outer
+// transaction.
+func (tx *NestedTx) Nested() bool {
+	return tx.savepoint != ""
+}
+
+// Commit commits the outermost transaction. Committing an inner
+// transaction keeps its changes as part of the outer transaction.
+func (tx *NestedTx) Commit(ctx context.Context) error {
+	if tx.conn == nil {
+		return errors.New("Transaction already finished")
+	}
+
+	conn := tx.conn
+	tx.conn = nil
+
+	if tx.Nested() {
+		return nil
+	}
+
+	if _, _, err := conn.GenericExec(ctx, "COMMIT TRANSACTION", nil); err != nil {
+		return fmt.Errorf("Failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// Rollback rolls back the outermost transaction or an inner
+// transaction to its savepoint.
+func (tx *NestedTx) Rollback(ctx context.Context) error {
+	if tx.conn == nil {
+		return errors.New("Transaction already finished")
+	}
+
+	conn := tx.conn
+	tx.conn = nil
+
+	if tx.Nested() {
+		return conn.RollbackTo(ctx, tx.savepoint)
+	}
+
+	if _, _, err := conn.GenericExec(ctx, "ROLLBACK TRANSACTION", nil); err != nil {
+		return fmt.Errorf("Failed to roll back transaction: %w", err)
+	}
+
+	return nil
+}
+
+// InNested runs fn in a transaction begun with BeginNested. The
+// transaction is committed if fn returns nil and rolled back if fn
+// returns an error or panics.
+func (conn *Connection) InNested(ctx context.Context, fn func() error) error {
+	tx, err := conn.BeginNested(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback(ctx)
+			panic(p)
+		}
+	}()
+
+	if err := fn(); err != nil {
+		if rbErr := tx.Rollback(ctx); rbErr != nil {
+			return fmt.Errorf("%w (rollback failed: %v)", err, rbErr)
+		}
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
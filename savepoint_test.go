@@ -0,0 +1,36 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckSavepointName(t *testing.T) {
+	cases := map[string]bool{
+		"sp1":                    true,
+		"_before_update":         true,
+		"cgoase_sp42":            true,
+		"name$1":                 true,
+		"":                       false,
+		"1sp":                    false,
+		"#sp":                    false,
+		"sp; drop table t":       false,
+		"sp name":                false,
+		"[sp]":                   false,
+		strings.Repeat("a", 256): false,
+	}
+
+	for name, valid := range cases {
+		err := checkSavepointName(name)
+		if valid && err != nil {
+			t.Errorf("%q: unexpected error: %v", name, err)
+		}
+		if !valid && err == nil {
+			t.Errorf("%q: expected error", name)
+		}
+	}
+}
@@ -14,7 +14,6 @@ import (
 	"fmt"
 	"io"
 	"math"
-	"strings"
 	"sync"
 	"unsafe"
 
@@ -28,14 +27,33 @@ var (
 	_ driver.StmtExecContext   = (*statement)(nil)
 	_ driver.StmtQueryContext  = (*statement)(nil)
 	_ driver.NamedValueChecker = (*statement)(nil)
+	_ ResultDescriber          = (*statement)(nil)
 )
 
 // statement implements the driver.Stmt interface.
 type statement struct {
-	name        string
-	argCount    int
+	name     string
+	argCount int
+	// paramNames contains the names of the placeholders of queries
+	// with named placeholders, see parseQuery.
+	paramNames  []string
 	cmd         *Command
 	columnTypes []ASEType
+	// columnPrecisions and columnScales are the precision and scale
+	// of DECIMAL and NUMERIC parameters.
+	columnPrecisions []int
+	columnScales     []int
+	// columnConverters contains the converters registered for the
+	// types of parameters, nil for parameters converted by the
+	// driver.
+	columnConverters []TypeConverter
+	// resultColumns describes the columns of the result set, set
+	// once described is set.
+	resultColumns []ColumnDescription
+	described     bool
+	// inserts and executes are set if the query inserts rows or
+	// executes procedures, see withLastInsertID.
+	inserts, executes bool
 }
 
 var (
@@ -49,22 +67,33 @@ func (conn *Connection) Prepare(query string) (driver.Stmt, error) {
 }
 
 // PrepareContext implements the driver.ConnPrepareContext interface.
+//
+// As the arguments are not known yet names in the query are
+// placeholders unless the query has positional placeholders.
 func (conn *Connection) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
-	return conn.prepare(ctx, query)
+	return conn.prepare(ctx, query, true)
 }
 
-// TODO: Add doc
-func (conn *Connection) prepare(ctx context.Context, query string) (*statement, error) {
+// prepare prepares query as dynamic SQL. If named is set names in the
+// query are placeholders, see parseQuery.
+func (conn *Connection) prepare(ctx context.Context, query string, named bool) (*statement, error) {
 	stmt := &statement{}
 
-	stmt.argCount = strings.Count(query, "?")
+	parsed, err := parseQuery(query, named)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing query: %w", err)
+	}
+
+	stmt.argCount = parsed.numParams
+	stmt.paramNames = parsed.paramNames
+	stmt.inserts, stmt.executes = parsed.inserts, parsed.executes
 
 	statementCounterM.Lock()
 	statementCounter++
 	stmt.name = fmt.Sprintf("stmt%d", statementCounter)
 	statementCounterM.Unlock()
 
-	cmd, err := conn.dynamic(stmt.name, query)
+	cmd, err := conn.dynamic(stmt.name, parsed.query)
 	if err != nil {
 		stmt.Close()
 		return nil, err
@@ -121,11 +150,21 @@ func (stmt *statement) Close() error {
 
 // NumInput implements the driver.Stmt interface.
 func (stmt *statement) NumInput() int {
+	// Named placeholders may occur multiple times, hence the number
+	// of arguments is checked when they are mapped.
+	if stmt.paramNames != nil {
+		return -1
+	}
 	return stmt.argCount
 }
 
 // TODO: Add doc
 func (stmt *statement) exec(ctx context.Context, args []driver.NamedValue) (*Rows, *Result, error) {
+	args, err := mapNamedArgs(stmt.paramNames, args)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	if len(args) != stmt.argCount {
 		return nil, nil, fmt.Errorf("Mismatched argument count - expected %d, got %d",
 			stmt.argCount, len(args))
@@ -134,158 +173,271 @@ func (stmt *statement) exec(ctx context.Context, args []driver.NamedValue) (*Row
 	name := C.CString(stmt.name)
 	defer C.free(unsafe.Pointer(name))
 
+	stmt.cmd.conn.resetMessages()
+
 	retval := C.ct_dynamic(stmt.cmd.cmd, C.CS_EXECUTE, name, C.CS_NULLTERM, nil, C.CS_UNUSED)
 	if retval != C.CS_SUCCEED {
 		return nil, nil, makeError(retval, "C.ct_dynamic with CS_EXECUTE failed")
 	}
 
 	for i, arg := range args {
-		// TODO place binding in function to achieve an earlier free
-		datafmt := (*C.CS_DATAFMT)(C.calloc(1, C.sizeof_CS_DATAFMT))
-		defer C.free(unsafe.Pointer(datafmt))
-		datafmt.status = C.CS_INPUTVALUE
-		datafmt.namelen = C.CS_NULLTERM
-
-		switch stmt.columnTypes[i] {
-		case IMAGE:
-			datafmt.datatype = (C.CS_INT)(BINARY)
-		default:
-			datafmt.datatype = (C.CS_INT)(stmt.columnTypes[i])
-		}
-
-		// datalen is the length of the data in bytes.
-		datalen := 0
-
-		dataType := stmt.columnTypes[i].ToDataType()
-
-		var ptr unsafe.Pointer
-		// TODO: This entire case could be moved into a function, since
-		// the values set here are always the same - the expected values
-		// for ct_param.
-		// This function could also check for null values early.
-		switch stmt.columnTypes[i] {
-		case BIGINT, INT, SMALLINT, TINYINT, UBIGINT, UINT, USMALLINT, USHORT, FLOAT, REAL:
-			bs, err := dataType.Bytes(binary.LittleEndian, arg.Value)
-			if err != nil {
-				// TODO context
-				return nil, nil, err
-			}
-			ptr = C.CBytes(bs)
-			defer C.free(ptr)
-		case DECIMAL, NUMERIC:
-			bs, err := dataType.Bytes(binary.LittleEndian, arg.Value)
-			if err != nil {
-				return nil, nil, err
-			}
+		if err := stmt.cmd.param(i+1, "", stmt.columnTypes[i], stmt.columnConverters[i], arg.Value); err != nil {
+			return nil, nil, err
+		}
+	}
 
-			csDec := (*C.CS_DECIMAL)(C.calloc(1, C.sizeof_CS_DECIMAL))
-			defer C.free(unsafe.Pointer(csDec))
-			csDec.precision = (C.CS_BYTE)(arg.Value.(*asetypes.Decimal).Precision)
-			csDec.scale = (C.CS_BYTE)(arg.Value.(*asetypes.Decimal).Scale)
+	// Return statuses of previous executions are not reported again.
+	stmt.cmd.resetResults()
+	retval = C.ct_send(stmt.cmd.cmd)
+	if retval != C.CS_SUCCEED {
+		return nil, nil, makeError(retval, "C.ct_send failed")
+	}
 
-			for i, b := range bs {
-				csDec.array[i] = (C.CS_BYTE)(b)
-			}
+	return stmt.cmd.ConsumeResponse(ctx)
+}
 
-			ptr = unsafe.Pointer(csDec)
-		case MONEY, MONEY4, DATE, TIME, DATETIME4, DATETIME, BIGDATETIME, BIGTIME:
-			bs, err := dataType.Bytes(binary.LittleEndian, arg.Value)
-			if err != nil {
-				return nil, nil, err
-			}
+// param passes value for the parameter with the passed 1-based index
+// and type to ct_param. If name is not empty the parameter is passed by
+// name.
+func (cmd *Command) param(index int, name string, t ASEType, conv TypeConverter, value driver.Value) error {
+	datafmt := (*C.CS_DATAFMT)(C.calloc(1, C.sizeof_CS_DATAFMT))
+	defer C.free(unsafe.Pointer(datafmt))
 
-			ptr = C.CBytes(bs)
-			defer C.free(ptr)
-		case CHAR:
-			ptr = unsafe.Pointer(C.CString(arg.Value.(string)))
-			defer C.free(ptr)
-
-			datalen = len(arg.Value.(string))
-			datafmt.format = C.CS_FMT_NULLTERM
-			datafmt.maxlength = C.CS_MAX_CHAR
-		case TEXT, LONGCHAR:
-			ptr = unsafe.Pointer(C.CString(arg.Value.(string)))
-			defer C.free(ptr)
-
-			datalen = len(arg.Value.(string))
-			datafmt.format = C.CS_FMT_NULLTERM
-			datafmt.maxlength = (C.CS_INT)(datalen)
-		case VARCHAR:
-			varchar := (*C.CS_VARCHAR)(C.calloc(1, C.sizeof_CS_VARCHAR))
-			defer C.free(unsafe.Pointer(varchar))
-			varchar.len = (C.CS_SMALLINT)(len(arg.Value.(string)))
-
-			for i, chr := range arg.Value.(string) {
-				varchar.str[i] = (C.CS_CHAR)(chr)
-			}
+	if err := initParamFormat(datafmt, name, t); err != nil {
+		return err
+	}
 
-			ptr = unsafe.Pointer(varchar)
-		case BINARY, IMAGE:
-			ptr = C.CBytes(arg.Value.([]byte))
-			defer C.free(ptr)
-			datalen = len(arg.Value.([]byte))
+	if value == nil {
+		if retval := C.ct_param(cmd.cmd, datafmt, nil, 0, -1); retval != C.CS_SUCCEED {
+			return makeError(retval, "C.ct_param on parameter %d failed with null", index)
+		}
+		return nil
+	}
 
-			// IMAGE does not support null padding
-			if stmt.columnTypes[i] == BINARY {
-				datafmt.format = C.CS_FMT_PADNULL
-			}
+	if loc, ok := value.(*Locator); ok && isLocatorType(t) {
+		if loc.loc == nil {
+			return fmt.Errorf("Locator passed for parameter %d is closed", index)
+		}
 
-			// The maximum length of slices is constrained by the
-			// ability to address elements by integers - hence the
-			// maximum length we can retrieve is MaxInt64.
-			datafmt.maxlength = (C.CS_INT)(math.MaxInt32)
-		case VARBINARY:
-			varbin := (*C.CS_VARBINARY)(C.calloc(1, C.sizeof_CS_VARBINARY))
-			defer C.free(unsafe.Pointer(varbin))
-			varbin.len = (C.CS_SMALLINT)(len(arg.Value.([]byte)))
-
-			for i, b := range arg.Value.([]byte) {
-				varbin.array[i] = (C.CS_BYTE)(b)
-			}
+		if retval := C.ct_param(cmd.cmd, datafmt, unsafe.Pointer(loc.loc), C.CS_UNUSED, 0); retval != C.CS_SUCCEED {
+			return makeError(retval, "C.ct_param on parameter %d failed with argument '%v'", index, value)
+		}
+		return nil
+	}
 
-			ptr = unsafe.Pointer(varbin)
-		case BIT:
-			b := (C.CS_BOOL)(0)
-			if arg.Value.(bool) {
-				b = (C.CS_BOOL)(1)
-			}
-			ptr = unsafe.Pointer(&b)
-			datalen = 1
-		case UNICHAR, UNITEXT:
-			bs, err := dataType.Bytes(binary.LittleEndian, arg.Value)
-			if err != nil {
-				return nil, nil, err
-			}
+	bs, datalen, err := cmd.paramData(index, datafmt, t, conv, value)
+	if err != nil {
+		return err
+	}
+
+	// C.CBytes allocates memory for empty slices as well, passing nil
+	// would send a null value.
+	ptr := C.CBytes(bs)
+	defer C.free(ptr)
+
+	retval := C.ct_param(cmd.cmd, datafmt, ptr, (C.CS_INT)(datalen), 0)
+	if retval != C.CS_SUCCEED {
+		return makeError(retval, "C.ct_param on parameter %d failed with argument '%v'", index, value)
+	}
+
+	return nil
+}
 
-			ptr = unsafe.Pointer(C.CBytes(bs))
-			defer C.free(ptr)
+// initParamFormat initializes datafmt for an input parameter of the
+// passed type. If name is not empty the parameter is passed by name.
+func initParamFormat(datafmt *C.CS_DATAFMT, name string, t ASEType) error {
+	datafmt.status = C.CS_INPUTVALUE
+	datafmt.namelen = C.CS_NULLTERM
 
-			datalen = len(bs)
-			datafmt.format = C.CS_FMT_NULLTERM
-			datafmt.maxlength = (C.CS_INT)(datalen)
-		default:
-			return nil, nil, fmt.Errorf("Unhandled column type: %s", stmt.columnTypes[i])
+	if name != "" {
+		if len(name) >= len(datafmt.name) {
+			return fmt.Errorf("Parameter name %q is too long", name)
 		}
 
-		var csDatalen C.CS_INT
-		if datalen != C.CS_UNUSED {
-			csDatalen = (C.CS_INT)(datalen)
-		} else {
-			csDatalen = C.CS_UNUSED
+		for j := 0; j < len(name); j++ {
+			datafmt.name[j] = (C.CS_CHAR)(name[j])
 		}
+	}
 
-		retval = C.ct_param(stmt.cmd.cmd, datafmt, ptr, csDatalen, 0)
-		if retval != C.CS_SUCCEED {
-			return nil, nil, makeError(retval, "C.ct_param on parameter %d failed with argument '%v'", i, arg)
+	switch t {
+	case IMAGE:
+		datafmt.datatype = (C.CS_INT)(BINARY)
+	default:
+		datafmt.datatype = (C.CS_INT)(t.baseType())
+	}
+
+	return nil
+}
+
+// paramData returns the bytes and the datalen passed to Client-Library
+// for the non-null value of a parameter of the passed type and adjusts
+// datafmt accordingly.
+func (cmd *Command) paramData(index int, datafmt *C.CS_DATAFMT, t ASEType, conv TypeConverter, value driver.Value) ([]byte, int, error) {
+	if conv != nil {
+		bs, err := conv.Bytes(value)
+		if err != nil {
+			return nil, 0, fmt.Errorf("Error converting parameter %d: %w", index, err)
+		}
+
+		// Variable length values are passed with their
+		// actual length, see varLenParam.
+		switch datafmt.datatype {
+		case C.CS_VARCHAR_TYPE:
+			datafmt.datatype = C.CS_CHAR_TYPE
+		case C.CS_VARBINARY_TYPE, C.CS_USER_TYPE:
+			datafmt.datatype = C.CS_BINARY_TYPE
 		}
+
+		datafmt.maxlength = (C.CS_INT)(len(bs))
+		return bs, len(bs), nil
 	}
 
-	retval = C.ct_send(stmt.cmd.cmd)
-	if retval != C.CS_SUCCEED {
-		return nil, nil, makeError(retval, "C.ct_send failed")
+	dataType := t.ToDataType()
+
+	switch t {
+	case BIGINT, INT, SMALLINT, TINYINT, UBIGINT, UINT, USMALLINT, USHORT, FLOAT, REAL,
+		MONEY, MONEY4, DATE, TIME, DATETIME4, DATETIME, BIGDATETIME, BIGTIME:
+		bs, err := dataType.Bytes(binary.LittleEndian, value)
+		if err != nil {
+			return nil, 0, fmt.Errorf("Error converting parameter %d: %w", index, err)
+		}
+		return bs, 0, nil
+	case DECIMAL, NUMERIC:
+		bs, err := dataType.Bytes(binary.LittleEndian, value)
+		if err != nil {
+			return nil, 0, fmt.Errorf("Error converting parameter %d: %w", index, err)
+		}
+
+		dec, ok := value.(*asetypes.Decimal)
+		if !ok {
+			return nil, 0, fmt.Errorf("Expected *asetypes.Decimal for parameter %d, received %T", index, value)
+		}
+
+		// Layout of CS_DECIMAL: precision, scale and the digits.
+		csDec := make([]byte, C.sizeof_CS_DECIMAL)
+		csDec[0] = byte(dec.Precision)
+		csDec[1] = byte(dec.Scale)
+		copy(csDec[2:], bs)
+
+		return csDec, 0, nil
+	case CHAR, VARCHAR, VARBINARY:
+		paramType, bs, err := varLenParam(t, value)
+		if err != nil {
+			return nil, 0, fmt.Errorf("Error converting parameter %d: %w", index, err)
+		}
+
+		datafmt.datatype = (C.CS_INT)(paramType)
+		datafmt.maxlength = (C.CS_INT)(len(bs))
+		return bs, len(bs), nil
+	case TEXT, LONGCHAR:
+		s, ok := value.(string)
+		if !ok {
+			return nil, 0, fmt.Errorf("Expected string for parameter %d, received %T", index, value)
+		}
+
+		datafmt.format = C.CS_FMT_NULLTERM
+		datafmt.maxlength = (C.CS_INT)(len(s))
+		return append([]byte(s), 0), len(s), nil
+	case BINARY, IMAGE, TIMESTAMP:
+		bs, ok := value.([]byte)
+		if !ok {
+			return nil, 0, fmt.Errorf("Expected []byte for parameter %d, received %T", index, value)
+		}
+
+		// IMAGE does not support null padding
+		if t != IMAGE {
+			datafmt.format = C.CS_FMT_PADNULL
+		}
+
+		// The maximum length of slices is constrained by the
+		// ability to address elements by integers - hence the
+		// maximum length we can retrieve is MaxInt64.
+		datafmt.maxlength = (C.CS_INT)(math.MaxInt32)
+		return bs, len(bs), nil
+	case BIT:
+		b, ok := value.(bool)
+		if !ok {
+			return nil, 0, fmt.Errorf("Expected bool for parameter %d, received %T", index, value)
+		}
+
+		if b {
+			return []byte{1}, 1, nil
+		}
+		return []byte{0}, 1, nil
+	case UNICHAR, UNITEXT, UNIVARCHAR:
+		s, ok := value.(string)
+		if !ok {
+			return nil, 0, fmt.Errorf("Expected string for parameter %d, received %T", index, value)
+		}
+
+		bs := encodeUTF16(s)
+		datafmt.format = C.CS_FMT_NULLTERM
+		datafmt.maxlength = (C.CS_INT)(len(bs))
+		return bs, len(bs), nil
+	case TEXTLOCATOR, IMAGELOCATOR, UNITEXTLOCATOR:
+		return nil, 0, fmt.Errorf("Expected *Locator for parameter %d, received %T", index, value)
+	default:
+		// Types unknown to the driver are converted from their
+		// string or binary form with cs_convert.
+		bs, err := fallbackParam(cmd.conn, t, value)
+		if err != nil {
+			return nil, 0, fmt.Errorf("Error converting parameter %d: %w", index, err)
+		}
+
+		datafmt.maxlength = (C.CS_INT)(len(bs))
+		return bs, len(bs), nil
 	}
+}
 
-	return stmt.cmd.ConsumeResponse(ctx)
+// fallbackParam converts a string or []byte passed for a parameter of a
+// type unknown to the driver into the passed type with cs_convert.
+func fallbackParam(conn *Connection, t ASEType, value interface{}) ([]byte, error) {
+	switch typed := value.(type) {
+	case string:
+		return csConvert(conn.driverCtx.ctx, []byte(typed), CHAR, t)
+	case []byte:
+		return csConvert(conn.driverCtx.ctx, typed, BINARY, t)
+	default:
+		return nil, fmt.Errorf("Unhandled column type %d for value of type %T", t, value)
+	}
+}
+
+// maxCharLength is the maximum length in bytes of CS_CHAR and CS_BINARY
+// values.
+const maxCharLength = C.CS_MAX_CHAR
+
+// varLenParam returns the datatype and the bytes to pass a value for a
+// character or binary parameter of the passed type to ct_param.
+//
+// CS_VARCHAR and CS_VARBINARY have a fixed buffer of CS_MAX_CHAR bytes,
+// hence values are passed as CS_CHAR and CS_BINARY with their length.
+// Values exceeding CS_MAX_CHAR bytes are passed as CS_LONGCHAR and
+// CS_LONGBINARY.
+func varLenParam(t ASEType, value interface{}) (ASEType, []byte, error) {
+	switch t {
+	case BINARY, VARBINARY, LONGBINARY:
+		bs, ok := value.([]byte)
+		if !ok {
+			return 0, nil, fmt.Errorf("expected []byte for %s, received %T", t, value)
+		}
+
+		if len(bs) > maxCharLength {
+			return LONGBINARY, bs, nil
+		}
+		return BINARY, bs, nil
+	case CHAR, VARCHAR, LONGCHAR:
+		s, ok := value.(string)
+		if !ok {
+			return 0, nil, fmt.Errorf("expected string for %s, received %T", t, value)
+		}
+
+		if len(s) > maxCharLength {
+			return LONGCHAR, []byte(s), nil
+		}
+		return CHAR, []byte(s), nil
+	default:
+		return 0, nil, fmt.Errorf("%s is not a character or binary type", t)
+	}
 }
 
 // Exec implements the driver.Stmt interface.
@@ -295,8 +447,20 @@ func (stmt *statement) Exec(args []driver.Value) (driver.Result, error) {
 
 // ExecContext implements the driver.StmtExecContext interface.
 func (stmt *statement) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
-	_, result, err := stmt.exec(ctx, args)
-	return result, err
+	rows, result, err := stmt.exec(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+
+	// Discard result sets so that @@identity can be read and collect
+	// the results of the statements following them.
+	if rows != nil {
+		if result, err = rows.consumeRemaining(ctx, result); err != nil {
+			return nil, err
+		}
+	}
+
+	return stmt.cmd.conn.withLastInsertID(ctx, stmt.inserts, stmt.executes, result)
 }
 
 // Query implements the driver.Stmt interface.
@@ -348,6 +512,9 @@ func (stmt *statement) fillColumnTypes() error {
 
 		stmt.argCount = int(paramCount)
 		stmt.columnTypes = make([]ASEType, stmt.argCount)
+		stmt.columnPrecisions = make([]int, stmt.argCount)
+		stmt.columnScales = make([]int, stmt.argCount)
+		stmt.columnConverters = make([]TypeConverter, stmt.argCount)
 
 		for i := 0; i < stmt.argCount; i++ {
 			datafmt := (*C.CS_DATAFMT)(C.calloc(1, C.sizeof_CS_DATAFMT))
@@ -357,7 +524,10 @@ func (stmt *statement) fillColumnTypes() error {
 				return makeError(retval, "Failed to retrieve description of parameter %d", i)
 			}
 
-			stmt.columnTypes[i] = ASEType(datafmt.datatype)
+			stmt.columnTypes[i] = ASEType(datafmt.datatype).withUsertype(int(datafmt.usertype))
+			stmt.columnPrecisions[i] = int(datafmt.precision)
+			stmt.columnScales[i] = int(datafmt.scale)
+			stmt.columnConverters[i] = stmt.cmd.conn.typeConverter(stmt.columnTypes[i], int(datafmt.usertype))
 		}
 
 	}
@@ -367,13 +537,61 @@ func (stmt *statement) fillColumnTypes() error {
 
 // CheckNamedValue implements the driver.NamedValueChecker interface.
 func (stmt statement) CheckNamedValue(named *driver.NamedValue) error {
-	index := named.Ordinal - 1
-	if index > len(stmt.columnTypes) {
+	index, err := paramIndex(stmt.paramNames, named)
+	if err != nil {
+		return fmt.Errorf("cgo-ase: %w", err)
+	}
+
+	if index >= len(stmt.columnTypes) {
 		return fmt.Errorf("cgo-ase: ordinal %d is larger than the number of columns %d",
 			named.Ordinal, len(stmt.columnTypes))
 	}
 
-	val, err := stmt.columnTypes[index].ToDataType().ConvertValue(named.Value)
+	if valuer, ok := named.Value.(driver.Valuer); ok {
+		val, err := valuer.Value()
+		if err != nil {
+			return fmt.Errorf("cgo-ase: error retrieving value: %w", err)
+		}
+		named.Value = val
+	}
+
+	// Null values are passed without conversion.
+	if named.Value == nil {
+		return nil
+	}
+
+	// Values of parameters with a registered converter are passed
+	// to the converter as-is.
+	if stmt.columnConverters[index] != nil {
+		return nil
+	}
+
+	// Parameters of types unknown to the driver accept the string or
+	// binary form of values, see fallbackParam.
+	if stmt.columnTypes[index].String() == "" {
+		switch named.Value.(type) {
+		case string, []byte:
+			return nil
+		}
+		return fmt.Errorf("cgo-ase: expected string or []byte for parameter of type %d, received %T",
+			stmt.columnTypes[index], named.Value)
+	}
+
+	if isLocatorType(stmt.columnTypes[index]) {
+		if _, ok := named.Value.(*Locator); !ok {
+			return fmt.Errorf("cgo-ase: expected *Locator for parameter of type %s, received %T",
+				stmt.columnTypes[index], named.Value)
+		}
+		return nil
+	}
+
+	val, err := paramValue(stmt.columnTypes[index], stmt.columnPrecisions[index],
+		stmt.columnScales[index], stmt.cmd.conn.native.paramLocation(), named.Value)
+	if err != nil {
+		return fmt.Errorf("cgo-ase: error converting value: %w", err)
+	}
+
+	val, err = stmt.columnTypes[index].ToDataType().ConvertValue(val)
 	if err != nil {
 		return fmt.Errorf("cgo-ase: error converting value: %w", err)
 	}
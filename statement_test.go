@@ -0,0 +1,62 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestVarLenParam(t *testing.T) {
+	cases := map[string]struct {
+		asetype   ASEType
+		value     interface{}
+		paramType ASEType
+		bs        []byte
+	}{
+		"varchar empty": {VARCHAR, "", CHAR, []byte{}},
+		"varchar 255":   {VARCHAR, strings.Repeat("a", 255), CHAR, []byte(strings.Repeat("a", 255))},
+		"varchar 256":   {VARCHAR, strings.Repeat("b", 256), CHAR, []byte(strings.Repeat("b", 256))},
+		"varchar 257":   {VARCHAR, strings.Repeat("c", 257), LONGCHAR, []byte(strings.Repeat("c", 257))},
+		"varchar 16384": {VARCHAR, strings.Repeat("d", 16384), LONGCHAR, []byte(strings.Repeat("d", 16384))},
+		// 128 two-byte runes exceed CS_MAX_CHAR bytes.
+		"varchar multibyte": {VARCHAR, strings.Repeat("ä", 129), LONGCHAR, []byte(strings.Repeat("ä", 129))},
+		"char 256":          {CHAR, strings.Repeat("e", 256), CHAR, []byte(strings.Repeat("e", 256))},
+		"varbinary empty":   {VARBINARY, []byte{}, BINARY, []byte{}},
+		"varbinary 255":     {VARBINARY, bytes.Repeat([]byte{0x1}, 255), BINARY, bytes.Repeat([]byte{0x1}, 255)},
+		"varbinary 256":     {VARBINARY, bytes.Repeat([]byte{0x2}, 256), BINARY, bytes.Repeat([]byte{0x2}, 256)},
+		"varbinary 257":     {VARBINARY, bytes.Repeat([]byte{0x3}, 257), LONGBINARY, bytes.Repeat([]byte{0x3}, 257)},
+		"varbinary 16384":   {VARBINARY, bytes.Repeat([]byte{0x4}, 16384), LONGBINARY, bytes.Repeat([]byte{0x4}, 16384)},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			paramType, bs, err := varLenParam(tc.asetype, tc.value)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if paramType != tc.paramType {
+				t.Errorf("Expected parameter type %s, received %s", tc.paramType, paramType)
+			}
+
+			if !bytes.Equal(bs, tc.bs) {
+				t.Errorf("Expected %d bytes, received %d bytes", len(tc.bs), len(bs))
+			}
+		})
+	}
+}
+
+func TestVarLenParamInvalid(t *testing.T) {
+	if _, _, err := varLenParam(VARCHAR, []byte("a")); err == nil {
+		t.Errorf("Expected error passing []byte for VARCHAR")
+	}
+
+	if _, _, err := varLenParam(VARBINARY, "a"); err == nil {
+		t.Errorf("Expected error passing string for VARBINARY")
+	}
+}
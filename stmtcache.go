@@ -0,0 +1,224 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"container/list"
+	"context"
+	"sync"
+)
+
+// StatementCacheStats are the counters of the prepared statement cache
+// of a connection.
+type StatementCacheStats struct {
+	// Hits is the number of executions using a cached statement.
+	Hits uint64
+	// Misses is the number of executions preparing a new statement.
+	Misses uint64
+	// Evictions is the number of statements removed from the cache
+	// because it was full or their execution failed.
+	Evictions uint64
+	// Size is the number of cached statements.
+	Size int
+}
+
+// stmtCache is a bounded LRU cache of prepared statements keyed by
+// their query.
+type stmtCache struct {
+	capacity int
+	// lru contains the cached statements, the most recently used
+	// statement first.
+	lru     *list.List
+	entries map[string]*list.Element
+	stats   StatementCacheStats
+	lock    sync.Mutex
+}
+
+type stmtCacheEntry struct {
+	query string
+	stmt  *statement
+}
+
+// newStmtCache returns a stmtCache holding up to capacity statements
+// or nil if capacity is not positive.
+func newStmtCache(capacity int) *stmtCache {
+	if capacity <= 0 {
+		return nil
+	}
+
+	return &stmtCache{
+		capacity: capacity,
+		lru:      list.New(),
+		entries:  map[string]*list.Element{},
+	}
+}
+
+// get returns the cached statement for query or nil.
+func (cache *stmtCache) get(query string) *statement {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	elem, ok := cache.entries[query]
+	if !ok {
+		cache.stats.Misses++
+		return nil
+	}
+
+	cache.stats.Hits++
+	cache.lru.MoveToFront(elem)
+	return elem.Value.(*stmtCacheEntry).stmt
+}
+
+// put adds stmt to the cache and returns the statements evicted to
+// make room, which must be closed by the caller.
+func (cache *stmtCache) put(query string, stmt *statement) []*statement {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	var evicted []*statement
+
+	if elem, ok := cache.entries[query]; ok {
+		evicted = append(evicted, elem.Value.(*stmtCacheEntry).stmt)
+		cache.lru.Remove(elem)
+		cache.stats.Evictions++
+	}
+
+	cache.entries[query] = cache.lru.PushFront(&stmtCacheEntry{query: query, stmt: stmt})
+
+	for cache.lru.Len() > cache.capacity {
+		elem := cache.lru.Back()
+		entry := cache.lru.Remove(elem).(*stmtCacheEntry)
+		delete(cache.entries, entry.query)
+		evicted = append(evicted, entry.stmt)
+		cache.stats.Evictions++
+	}
+
+	return evicted
+}
+
+// remove removes the statement cached for query and returns it or nil.
+func (cache *stmtCache) remove(query string) *statement {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	elem, ok := cache.entries[query]
+	if !ok {
+		return nil
+	}
+
+	delete(cache.entries, query)
+	cache.lru.Remove(elem)
+	cache.stats.Evictions++
+	return elem.Value.(*stmtCacheEntry).stmt
+}
+
+// clear removes all statements and returns them.
+func (cache *stmtCache) clear() []*statement {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	stmts := make([]*statement, 0, cache.lru.Len())
+	for elem := cache.lru.Front(); elem != nil; elem = elem.Next() {
+		stmts = append(stmts, elem.Value.(*stmtCacheEntry).stmt)
+	}
+
+	cache.lru.Init()
+	cache.entries = map[string]*list.Element{}
+	return stmts
+}
+
+// StatementCacheStats returns the counters of the prepared statement
+// cache. If the cache is disabled all counters are zero.
+func (conn *Connection) StatementCacheStats() StatementCacheStats {
+	if conn.stmtCache == nil {
+		return StatementCacheStats{}
+	}
+
+	conn.stmtCache.lock.Lock()
+	defer conn.stmtCache.lock.Unlock()
+
+	stats := conn.stmtCache.stats
+	stats.Size = conn.stmtCache.lru.Len()
+	return stats
+}
+
+// stmtCacheKey returns the key statements prepared for query are cached
+// with. Queries with names are prepared differently depending on named,
+// see parseQuery. As queries cannot contain null bytes the keys cannot
+// collide.
+func stmtCacheKey(query string, named bool) string {
+	if named {
+		return "\x00" + query
+	}
+	return query
+}
+
+// cachedStatement returns the cached statement for query or prepares a
+// new statement and adds it to the cache.
+func (conn *Connection) cachedStatement(ctx context.Context, query string, named bool) (*statement, error) {
+	key := stmtCacheKey(query, named)
+	if stmt := conn.stmtCache.get(key); stmt != nil {
+		return stmt, nil
+	}
+
+	stmt, err := conn.prepare(ctx, query, named)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, evicted := range conn.stmtCache.put(key, stmt) {
+		evicted.Close()
+	}
+
+	return stmt, nil
+}
+
+// invalidateStatement removes the statement cached for query.
+func (conn *Connection) invalidateStatement(query string, named bool) {
+	if stmt := conn.stmtCache.remove(stmtCacheKey(query, named)); stmt != nil {
+		stmt.Close()
+	}
+}
+
+// Numbers of the server messages signalling that a prepared statement
+// must be prepared again.
+const (
+	// msgNumberSchemaChanged is sent if the schema of a table changed
+	// since the statement was compiled.
+	msgNumberSchemaChanged = 540
+	// msgNumberDefinitionChanged is sent if the definition of an
+	// object changed since the statement was compiled.
+	msgNumberDefinitionChanged = 2801
+	// msgNumberDynamicNotFound is sent if the dynamic statement does
+	// not exist on the server, e.g. after it was deallocated.
+	msgNumberDynamicNotFound = 7760
+)
+
+// invalidatesStatement returns true if one of the passed server
+// messages signals that a prepared statement must be prepared again.
+func invalidatesStatement(messages []ServerMessage) bool {
+	for _, msg := range messages {
+		switch msg.MsgNumber {
+		case msgNumberSchemaChanged, msgNumberDefinitionChanged, msgNumberDynamicNotFound:
+			return true
+		}
+	}
+	return false
+}
+
+// invalidateFailedStatement removes the statement cached for query
+// after its execution failed if the server messages received for the
+// execution signal a schema change or that the statement was not
+// found. Statements failing for other reasons, e.g. constraint
+// violations, stay cached.
+func (conn *Connection) invalidateFailedStatement(query string, named bool) {
+	conn.messagesLock.Lock()
+	invalidated := invalidatesStatement(conn.errorMessages)
+	conn.messagesLock.Unlock()
+
+	if invalidated {
+		conn.invalidateStatement(query, named)
+	}
+}
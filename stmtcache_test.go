@@ -0,0 +1,117 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import "testing"
+
+func TestStmtCache(t *testing.T) {
+	if cache := newStmtCache(0); cache != nil {
+		t.Errorf("Expected disabled cache for capacity 0")
+	}
+
+	cache := newStmtCache(2)
+	a, b, c := &statement{name: "a"}, &statement{name: "b"}, &statement{name: "c"}
+
+	if stmt := cache.get("a"); stmt != nil {
+		t.Errorf("Expected miss on empty cache, received %s", stmt.name)
+	}
+
+	if evicted := cache.put("a", a); len(evicted) != 0 {
+		t.Errorf("Expected no evictions, received %d", len(evicted))
+	}
+	cache.put("b", b)
+
+	// Use a to make b the least recently used statement.
+	if stmt := cache.get("a"); stmt != a {
+		t.Errorf("Expected hit for a")
+	}
+
+	evicted := cache.put("c", c)
+	if len(evicted) != 1 || evicted[0] != b {
+		t.Errorf("Expected b to be evicted, received %v", evicted)
+	}
+
+	if stmt := cache.get("b"); stmt != nil {
+		t.Errorf("Expected miss for evicted statement")
+	}
+
+	if stmt := cache.remove("c"); stmt != c {
+		t.Errorf("Expected c to be removed")
+	}
+
+	if stmt := cache.remove("c"); stmt != nil {
+		t.Errorf("Expected nil removing c twice")
+	}
+
+	if stmts := cache.clear(); len(stmts) != 1 || stmts[0] != a {
+		t.Errorf("Expected clear to return a, received %v", stmts)
+	}
+
+	expected := StatementCacheStats{Hits: 1, Misses: 2, Evictions: 2}
+	if cache.stats != expected {
+		t.Errorf("Expected stats %+v, received %+v", expected, cache.stats)
+	}
+}
+
+func TestStmtCacheKey(t *testing.T) {
+	query := "select * from t where a = @a"
+
+	if stmtCacheKey(query, false) != query {
+		t.Errorf("Expected query as key of positional statement")
+	}
+
+	if stmtCacheKey(query, true) == stmtCacheKey(query, false) {
+		t.Errorf("Expected different keys for named and positional statements")
+	}
+}
+
+func TestInvalidateFailedStatement(t *testing.T) {
+	query := "update t set a = ? where id = ?"
+
+	cases := map[string]struct {
+		messages []ServerMessage
+		evicted  bool
+	}{
+		"no messages": {
+			messages: nil,
+			evicted:  false,
+		},
+		"constraint violation": {
+			messages: []ServerMessage{{MsgNumber: 2601, Severity: 14}},
+			evicted:  false,
+		},
+		"row changed": {
+			messages: []ServerMessage{{MsgNumber: msgNumberRowChanged, Severity: 16}},
+			evicted:  false,
+		},
+		"schema changed": {
+			messages: []ServerMessage{{MsgNumber: msgNumberSchemaChanged, Severity: 16}},
+			evicted:  true,
+		},
+		"definition changed": {
+			messages: []ServerMessage{{MsgNumber: 208, Severity: 16}, {MsgNumber: msgNumberDefinitionChanged, Severity: 16}},
+			evicted:  true,
+		},
+		"dynamic statement not found": {
+			messages: []ServerMessage{{MsgNumber: msgNumberDynamicNotFound, Severity: 16}},
+			evicted:  true,
+		},
+	}
+
+	for title, cas := range cases {
+		t.Run(title, func(t *testing.T) {
+			conn := &Connection{stmtCache: newStmtCache(2), errorMessages: cas.messages}
+			stmt := &statement{name: "stmt"}
+			conn.stmtCache.put(stmtCacheKey(query, false), stmt)
+
+			conn.invalidateFailedStatement(query, false)
+
+			cached := conn.stmtCache.get(stmtCacheKey(query, false)) == stmt
+			if cached == cas.evicted {
+				t.Errorf("Expected evicted to be %t", cas.evicted)
+			}
+		})
+	}
+}
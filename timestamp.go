@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+)
+
+// Interface satisfaction checks.
+var (
+	_ driver.Valuer = Timestamp{}
+	_ sql.Scanner   = (*Timestamp)(nil)
+)
+
+// Timestamp is the value of a timestamp column.
+//
+// ASE increments the timestamp of a database with each modification
+// of a row with a timestamp column and stores the new value in the row.
+// Timestamps are only meaningful in relation to other timestamps of the
+// same database and are compared bytewise.
+type Timestamp [8]byte
+
+// Compare returns an integer comparing two timestamps. The result is
+// 0 if ts == other, -1 if ts < other and +1 if ts > other.
+func (ts Timestamp) Compare(other Timestamp) int {
+	return bytes.Compare(ts[:], other[:])
+}
+
+// Equal reports whether ts and other are equal.
+func (ts Timestamp) Equal(other Timestamp) bool {
+	return ts == other
+}
+
+// Before reports whether ts is older than other.
+func (ts Timestamp) Before(other Timestamp) bool {
+	return ts.Compare(other) < 0
+}
+
+// After reports whether ts is newer than other.
+func (ts Timestamp) After(other Timestamp) bool {
+	return ts.Compare(other) > 0
+}
+
+// String returns the timestamp as hexadecimal literal.
+func (ts Timestamp) String() string {
+	return "0x" + hex.EncodeToString(ts[:])
+}
+
+// Value implements the driver.Valuer interface.
+func (ts Timestamp) Value() (driver.Value, error) {
+	return ts[:], nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (ts *Timestamp) Scan(src interface{}) error {
+	switch value := src.(type) {
+	case Timestamp:
+		*ts = value
+	case []byte:
+		if len(value) != len(ts) {
+			return fmt.Errorf("cgo-ase: timestamp requires %d bytes, received %d", len(ts), len(value))
+		}
+		copy(ts[:], value)
+	default:
+		return fmt.Errorf("cgo-ase: cannot scan %T into Timestamp", src)
+	}
+
+	return nil
+}
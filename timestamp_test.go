@@ -0,0 +1,66 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import "testing"
+
+func TestTimestampCompare(t *testing.T) {
+	cases := map[string]struct {
+		a, b    Timestamp
+		compare int
+	}{
+		"equal": {
+			Timestamp{0, 0, 0, 0, 0, 0, 0x10, 0x01},
+			Timestamp{0, 0, 0, 0, 0, 0, 0x10, 0x01},
+			0,
+		},
+		"lower byte": {
+			Timestamp{0, 0, 0, 0, 0, 0, 0x10, 0x01},
+			Timestamp{0, 0, 0, 0, 0, 0, 0x10, 0x02},
+			-1,
+		},
+		"higher byte": {
+			Timestamp{0, 0, 0, 0, 0, 0x01, 0x00, 0x00},
+			Timestamp{0, 0, 0, 0, 0, 0, 0xff, 0xff},
+			1,
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			if c := tc.a.Compare(tc.b); c != tc.compare {
+				t.Errorf("Expected Compare to return %d, received %d", tc.compare, c)
+			}
+
+			if tc.a.Equal(tc.b) != (tc.compare == 0) {
+				t.Errorf("Equal returned %t for %s and %s", tc.a.Equal(tc.b), tc.a, tc.b)
+			}
+
+			if tc.a.Before(tc.b) != (tc.compare < 0) {
+				t.Errorf("Before returned %t for %s and %s", tc.a.Before(tc.b), tc.a, tc.b)
+			}
+
+			if tc.a.After(tc.b) != (tc.compare > 0) {
+				t.Errorf("After returned %t for %s and %s", tc.a.After(tc.b), tc.a, tc.b)
+			}
+		})
+	}
+}
+
+func TestTimestampScan(t *testing.T) {
+	var ts Timestamp
+	if err := ts.Scan([]byte{1, 2, 3, 4, 5, 6, 7, 8}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if ts != (Timestamp{1, 2, 3, 4, 5, 6, 7, 8}) {
+		t.Errorf("Unexpected timestamp %s", ts)
+	}
+
+	if err := ts.Scan([]byte{1, 2, 3}); err == nil {
+		t.Errorf("Expected error scanning three bytes")
+	}
+}
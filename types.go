@@ -14,9 +14,34 @@ import (
 // ASEType is the byte-representation of an ASE-datatype.
 type ASEType byte
 
+// ASETypes of datatypes Client-Library describes as their base type.
+// These are identified by their usertype and are outside of the range
+// of the generated ASETypes.
+const (
+	// TIMESTAMP is described as BINARY.
+	TIMESTAMP ASEType = 200
+	// UNIVARCHAR is described as UNICHAR.
+	UNIVARCHAR ASEType = 201
+)
+
+// Usertypes of the datatypes identified by their usertype.
+const (
+	usertypeUnivarchar = 35
+	usertypeTimestamp  = 80
+)
+
+var usertype2string = map[ASEType]string{
+	TIMESTAMP:  "TIMESTAMP",
+	UNIVARCHAR: "UNIVARCHAR",
+}
+
 // String returns the ASEType as string and satisfies the
 // stringer interface.
 func (t ASEType) String() string {
+	if s, ok := usertype2string[t]; ok {
+		return s
+	}
+
 	s, ok := type2string[t]
 	if !ok {
 		return ""
@@ -24,6 +49,32 @@ func (t ASEType) String() string {
 	return s
 }
 
+// withUsertype returns the ASEType for datatypes which are identified
+// by their usertype. If the usertype does not identify a datatype the
+// base type is returned.
+func (t ASEType) withUsertype(usertype int) ASEType {
+	switch {
+	case t == BINARY && usertype == usertypeTimestamp:
+		return TIMESTAMP
+	case t == UNICHAR && usertype == usertypeUnivarchar:
+		return UNIVARCHAR
+	default:
+		return t
+	}
+}
+
+// baseType returns the ASEType Client-Library uses for the datatype.
+func (t ASEType) baseType() ASEType {
+	switch t {
+	case TIMESTAMP:
+		return BINARY
+	case UNIVARCHAR:
+		return UNICHAR
+	default:
+		return t
+	}
+}
+
 // ToDataType returns the equivalent asetypes.DataType for an ASEType.
 func (t ASEType) ToDataType() asetypes.DataType {
 	switch t {
@@ -56,7 +107,7 @@ func (t ASEType) ToDataType() asetypes.DataType {
 	case IMAGE:
 		return asetypes.IMAGE
 	case IMAGELOCATOR:
-		// TODO
+		// Locators are not converted, see Locator.
 		return 0
 	case INT:
 		return asetypes.INT4
@@ -81,20 +132,22 @@ func (t ASEType) ToDataType() asetypes.DataType {
 	case TEXT:
 		return asetypes.TEXT
 	case TEXTLOCATOR:
-		// TODO
+		// Locators are not converted, see Locator.
 		return 0
 	case TIME:
 		return asetypes.TIME
+	case TIMESTAMP:
+		return asetypes.BINARY
 	case TINYINT:
 		return asetypes.INT1
 	case UBIGINT:
 		return asetypes.UINT8
 	case UINT:
 		return asetypes.UINT4
-	case UNICHAR, UNITEXT:
+	case UNICHAR, UNITEXT, UNIVARCHAR:
 		return asetypes.UNITEXT
 	case UNITEXTLOCATOR:
-		// TODO
+		// Locators are not converted, see Locator.
 		return 0
 	case USER:
 		// TODO
@@ -0,0 +1,34 @@
+// SPDX-FileCopyrightText: 2020 - 2025 SAP SE
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ase
+
+import (
+	"encoding/binary"
+	"unicode/utf16"
+)
+
+// decodeUTF16 decodes UTF-16 as copied by Client-Library into a string.
+func decodeUTF16(bs []byte) string {
+	u := make([]uint16, len(bs)/2)
+	for i := range u {
+		u[i] = binary.LittleEndian.Uint16(bs[2*i:])
+	}
+
+	return string(utf16.Decode(u))
+}
+
+// encodeUTF16 encodes a string as UTF-16 as expected by Client-Library.
+// The length of the returned slice is the length of the value in bytes,
+// which is twice the number of UTF-16 code units.
+func encodeUTF16(s string) []byte {
+	u := utf16.Encode([]rune(s))
+
+	bs := make([]byte, 2*len(u))
+	for i, c := range u {
+		binary.LittleEndian.PutUint16(bs[2*i:], c)
+	}
+
+	return bs
+}